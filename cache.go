@@ -0,0 +1,219 @@
+package jira
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cache stores the responses for GET requests on disk so metadata that rarely
+// changes (fields, createmeta, editmeta, transitions, serverInfo) does not need
+// to be fetched for every command.
+type Cache struct {
+	// Dir is the directory the responses are stored in
+	Dir string
+	// TTL is how long a response is used before it is fetched again
+	TTL time.Duration
+	// IssueTTL is how long the editmeta and transitions for an issue are
+	// used, these change with the issue status so should be kept short
+	IssueTTL time.Duration
+	// Login is the user the responses were fetched for, the editmeta and
+	// transitions depend on the permissions of the user so the responses are
+	// not shared between logins
+	Login string
+}
+
+// CacheEntry is a response stored in the Cache
+type CacheEntry struct {
+	URI     string          `json:"uri" yaml:"uri"`
+	Login   string          `json:"login" yaml:"login"`
+	Fetched time.Time       `json:"fetched" yaml:"fetched"`
+	Expires time.Time       `json:"expires" yaml:"expires"`
+	Size    int             `json:"size" yaml:"size"`
+	Body    json.RawMessage `json:"body,omitempty" yaml:"-"`
+}
+
+var (
+	issueMetaPath   = regexp.MustCompile(`/rest/api/2/issue/[^/]+/(editmeta|transitions)$`)
+	issueChangePath = regexp.MustCompile(`/rest/api/2/issue/([^/]+)(/transitions)?$`)
+)
+
+func (c *Cache) ttl(uri string) time.Duration {
+	if u, err := url.Parse(uri); err == nil && issueMetaPath.MatchString(u.Path) && c.IssueTTL < c.TTL {
+		return c.IssueTTL
+	}
+	return c.TTL
+}
+
+func (c *Cache) file(uri string) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%x.json", sha1.Sum([]byte(c.Login+"\n"+uri))))
+}
+
+func (c *Cache) read(file string) (*CacheEntry, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	entry := &CacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Get returns the cached response body for the uri, or nil if it is missing
+// or expired.
+func (c *Cache) Get(uri string) []byte {
+	entry, err := c.read(c.file(uri))
+	if err != nil || entry.URI != uri || entry.Login != c.Login || time.Now().After(entry.Expires) {
+		return nil
+	}
+	return entry.Body
+}
+
+// Set will store the response body for the uri.
+func (c *Cache) Set(uri string, body []byte) error {
+	if c.ttl(uri) <= 0 {
+		return nil
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	now := time.Now()
+	encoded, err := json.Marshal(&CacheEntry{
+		URI:     uri,
+		Login:   c.Login,
+		Fetched: now,
+		Expires: now.Add(c.ttl(uri)),
+		Size:    len(body),
+		Body:    json.RawMessage(body),
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.file(uri), encoded, 0600)
+}
+
+// Entries returns all the responses stored in the cache for every login,
+// without the bodies, sorted by uri.
+func (c *Cache) Entries() ([]*CacheEntry, error) {
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := []*CacheEntry{}
+	for _, file := range files {
+		entry, err := c.read(file)
+		if err != nil {
+			continue
+		}
+		entry.Body = nil
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].URI < entries[j].URI
+	})
+	return entries, nil
+}
+
+// Clear will remove all the responses stored in the cache.
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// invalidate will remove the cached editmeta and transitions for the issue
+// when the uri is modifying, transitioning or deleting the issue.
+func (c *Cache) invalidate(uri string) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return
+	}
+	if match := issueChangePath.FindStringSubmatch(u.Path); match != nil {
+		c.Invalidate(match[1])
+	}
+}
+
+// Invalidate will remove the cached editmeta and transitions for the issue
+// for every login.  This should be called after the issue is changed
+// without going through the CacheClient.
+func (c *Cache) Invalidate(issue string) {
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		entry, err := c.read(file)
+		if err != nil {
+			continue
+		}
+		if eu, err := url.Parse(entry.URI); err == nil && issueMetaPath.MatchString(eu.Path) && strings.Contains(eu.Path, "/rest/api/2/issue/"+issue+"/") {
+			os.Remove(file)
+		}
+	}
+}
+
+// CacheClient wraps an HttpClient so successful GET responses are served
+// from the Cache.  Editing, transitioning or deleting an issue will
+// invalidate the cached editmeta and transitions for that issue.
+type CacheClient struct {
+	HttpClient
+	Cache *Cache
+}
+
+// NewCacheClient returns a CacheClient using the cache for the ua.
+func NewCacheClient(ua HttpClient, cache *Cache) *CacheClient {
+	return &CacheClient{
+		HttpClient: ua,
+		Cache:      cache,
+	}
+}
+
+func (c *CacheClient) GetJSON(uri string) (*http.Response, error) {
+	if body := c.Cache.Get(uri); body != nil {
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+	resp, err := c.HttpClient.GetJSON(uri)
+	if err != nil || resp.StatusCode != 200 {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	// errors writing the cache are not fatal, we will just fetch it again
+	c.Cache.Set(uri, body)
+	return resp, nil
+}
+
+func (c *CacheClient) Delete(uri string) (*http.Response, error) {
+	c.Cache.invalidate(uri)
+	return c.HttpClient.Delete(uri)
+}
+
+func (c *CacheClient) Post(uri, bodyType string, body io.Reader) (*http.Response, error) {
+	c.Cache.invalidate(uri)
+	return c.HttpClient.Post(uri, bodyType, body)
+}
+
+func (c *CacheClient) Put(uri, bodyType string, body io.Reader) (*http.Response, error) {
+	c.Cache.invalidate(uri)
+	return c.HttpClient.Put(uri, bodyType, body)
+}
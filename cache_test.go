@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheLogin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	uri := "https://jira.example.com/rest/api/2/issue/TEST-1/editmeta"
+	alice := &Cache{Dir: dir, TTL: time.Hour, IssueTTL: time.Minute, Login: "alice"}
+	bob := &Cache{Dir: dir, TTL: time.Hour, IssueTTL: time.Minute, Login: "bob"}
+	assert.NoError(t, alice.Set(uri, []byte(`{"fields":{}}`)))
+	assert.Equal(t, []byte(`{"fields":{}}`), alice.Get(uri))
+	assert.Nil(t, bob.Get(uri))
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	endpoint := "https://jira.example.com/rest/api/2"
+	cache := &Cache{Dir: dir, TTL: time.Hour, IssueTTL: time.Minute, Login: "alice"}
+	other := &Cache{Dir: dir, TTL: time.Hour, IssueTTL: time.Minute, Login: "bob"}
+	for _, uri := range []string{"/issue/TEST-1/editmeta", "/issue/TEST-1/transitions", "/issue/TEST-12/editmeta", "/field"} {
+		assert.NoError(t, cache.Set(endpoint+uri, []byte(`{}`)))
+	}
+	assert.NoError(t, other.Set(endpoint+"/issue/TEST-1/transitions", []byte(`{}`)))
+
+	// editing the issue discards the metadata of that issue for every login
+	cache.invalidate(endpoint + "/issue/TEST-1")
+	assert.Nil(t, cache.Get(endpoint+"/issue/TEST-1/editmeta"))
+	assert.Nil(t, cache.Get(endpoint+"/issue/TEST-1/transitions"))
+	assert.Nil(t, other.Get(endpoint+"/issue/TEST-1/transitions"))
+	assert.NotNil(t, cache.Get(endpoint+"/issue/TEST-12/editmeta"))
+	assert.NotNil(t, cache.Get(endpoint+"/field"))
+
+	// other requests keep the cache
+	cache.invalidate(endpoint + "/issue/TEST-12/comment")
+	assert.NotNil(t, cache.Get(endpoint+"/issue/TEST-12/editmeta"))
+	cache.Invalidate("TEST-12")
+	assert.Nil(t, cache.Get(endpoint+"/issue/TEST-12/editmeta"))
+}
@@ -13,49 +13,74 @@ import (
 )
 
 // https://docs.atlassian.com/jira-software/REST/latest/#agile/1.0/epic-getIssuesForEpic
-func (j *Jira) EpicSearch(epic string, sp SearchProvider) (*jiradata.SearchResults, error) {
-	return EpicSearch(j.UA, j.Endpoint, epic, sp)
+func (j *Jira) EpicSearch(epic string, sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchResults, error) {
+	return EpicSearch(j.UA, j.Endpoint, epic, sp, opts...)
 }
 
-func EpicSearch(ua HttpClient, endpoint string, epic string, sp SearchProvider) (*jiradata.SearchResults, error) {
+func EpicSearch(ua HttpClient, endpoint string, epic string, sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchResults, error) {
+	c := &searchConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	req := sp.ProvideSearchRequest()
+	limit := req.MaxResults
 	// encoded, err := json.Marshal(req)
 	// if err != nil {
 	// 	return nil, err
 	// }
-	uri, err := url.Parse(URLJoin(endpoint, "rest/agile/1.0/epic", epic, "issue"))
-	if err != nil {
-		return nil, err
-	}
-	params := url.Values{}
-	if len(req.Fields) > 0 {
-		params.Add("fields", strings.Join(req.Fields, ","))
-	}
-	if req.JQL != "" {
-		params.Add("jql", req.JQL)
-	}
-	if req.MaxResults != 0 {
-		params.Add("maxResults", fmt.Sprintf("%d", req.MaxResults))
-	}
-	if req.StartAt != 0 {
-		params.Add("startAt", fmt.Sprintf("%d", req.StartAt))
-	}
-	if req.ValidateQuery != "" {
-		params.Add("validateQuery", req.ValidateQuery)
-	}
-	uri.RawQuery = params.Encode()
-
-	resp, err := ua.Do(oreo.RequestBuilder(uri).WithHeader("Accept", "application/json").Build())
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 200 {
-		results := &jiradata.SearchResults{}
-		return results, json.NewDecoder(resp.Body).Decode(results)
+	issues := jiradata.Issues{}
+	for {
+		uri, err := url.Parse(URLJoin(endpoint, "rest/agile/1.0/epic", epic, "issue"))
+		if err != nil {
+			return nil, err
+		}
+		params := url.Values{}
+		if len(req.Fields) > 0 {
+			params.Add("fields", strings.Join(req.Fields, ","))
+		}
+		if req.JQL != "" {
+			params.Add("jql", req.JQL)
+		}
+		if req.MaxResults != 0 {
+			params.Add("maxResults", fmt.Sprintf("%d", req.MaxResults))
+		}
+		if req.StartAt != 0 {
+			params.Add("startAt", fmt.Sprintf("%d", req.StartAt))
+		}
+		if req.ValidateQuery != "" {
+			params.Add("validateQuery", req.ValidateQuery)
+		}
+		uri.RawQuery = params.Encode()
+
+		resp, err := ua.Do(oreo.RequestBuilder(uri).WithHeader("Accept", "application/json").Build())
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != 200 {
+			return nil, responseError(resp)
+		}
+		page := &jiradata.SearchResults{}
+		if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+			return nil, err
+		}
+		if !c.autoPaginate {
+			return page, nil
+		}
+
+		issues = append(issues, page.Issues...)
+		// the page size is chosen by the server, so stop on an empty page
+		if (limit > 0 && len(issues) >= limit) || len(issues) >= page.Total || len(page.Issues) == 0 {
+			page.Issues = issues
+			return page, nil
+		}
+		req.StartAt = len(issues)
+		if limit > 0 && len(issues)+req.MaxResults > limit {
+			req.MaxResults = limit - len(issues)
+		}
 	}
-	return nil, responseError(resp)
 }
 
 type EpicIssuesProvider interface {
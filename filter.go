@@ -0,0 +1,102 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/filter-getFilter
+func (j *Jira) GetFilter(id string) (*jiradata.Filter, error) {
+	return GetFilter(j.UA, j.Endpoint, id)
+}
+
+func GetFilter(ua HttpClient, endpoint string, id string) (*jiradata.Filter, error) {
+	uri := URLJoin(endpoint, "rest/api/2/filter", id)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Filter{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/filter-getFavouriteFilters
+func (j *Jira) GetFavouriteFilters() (*jiradata.Filters, error) {
+	return GetFavouriteFilters(j.UA, j.Endpoint)
+}
+
+func GetFavouriteFilters(ua HttpClient, endpoint string) (*jiradata.Filters, error) {
+	uri := URLJoin(endpoint, "rest/api/2/filter/favourite")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := jiradata.Filters{}
+		return &results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+type FilterProvider interface {
+	ProvideFilter() *jiradata.Filter
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/filter-createFilter
+func (j *Jira) CreateFilter(fp FilterProvider) (*jiradata.Filter, error) {
+	return CreateFilter(j.UA, j.Endpoint, fp)
+}
+
+func CreateFilter(ua HttpClient, endpoint string, fp FilterProvider) (*jiradata.Filter, error) {
+	req := fp.ProvideFilter()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/filter")
+	resp, err := ua.Post(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Filter{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/filter-editFilter
+func (j *Jira) UpdateFilter(id string, fp FilterProvider) (*jiradata.Filter, error) {
+	return UpdateFilter(j.UA, j.Endpoint, id, fp)
+}
+
+func UpdateFilter(ua HttpClient, endpoint string, id string, fp FilterProvider) (*jiradata.Filter, error) {
+	req := fp.ProvideFilter()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/filter", id)
+	resp, err := ua.Put(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Filter{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
@@ -0,0 +1,49 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-group-member-get
+func (j *Jira) GetGroupMembers(group string) (*jiradata.GroupMembers, error) {
+	return GetGroupMembers(j.UA, j.Endpoint, group)
+}
+
+// GetGroupMembers will fetch every page of members for the group and return
+// them as a single result.
+func GetGroupMembers(ua HttpClient, endpoint string, group string) (*jiradata.GroupMembers, error) {
+	results := &jiradata.GroupMembers{}
+	for {
+		page, err := getGroupMembersPage(ua, endpoint, group, len(results.Values))
+		if err != nil {
+			return nil, err
+		}
+		results.Values = append(results.Values, page.Values...)
+		results.Total = page.Total
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+	results.IsLast = true
+	results.MaxResults = len(results.Values)
+	return results, nil
+}
+
+func getGroupMembersPage(ua HttpClient, endpoint string, group string, startAt int) (*jiradata.GroupMembers, error) {
+	uri := URLJoin(endpoint, "rest/api/2/group/member") + fmt.Sprintf("?groupname=%s&startAt=%d", url.QueryEscape(group), startAt)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.GroupMembers{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
@@ -109,6 +109,53 @@ func GetIssueWorklog(ua HttpClient, endpoint string, issue string) (*jiradata.Wo
 	return &worklogs, nil
 }
 
+func (j *Jira) GetIssueChangelog(issue string) (jiradata.Histories, error) {
+	return GetIssueChangelog(j.UA, j.Endpoint, issue)
+}
+
+// changelogPage is a page of the issue changelog, the histories are in values
+// rather than histories like the changelog expanded in the issue.
+type changelogPage struct {
+	MaxResults int                `json:"maxResults,omitempty"`
+	StartAt    int                `json:"startAt,omitempty"`
+	Total      int                `json:"total,omitempty"`
+	Values     jiradata.Histories `json:"values,omitempty"`
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issues/#api-rest-api-2-issue-issueidorkey-changelog-get
+func GetIssueChangelog(ua HttpClient, endpoint string, issue string) (jiradata.Histories, error) {
+	startAt := 0
+	total := 1
+	maxResults := 100
+	histories := jiradata.Histories{}
+	for startAt < total {
+		uri := URLJoin(endpoint, "rest/api/2/issue", issue, "changelog")
+		uri += fmt.Sprintf("?startAt=%d&maxResults=%d", startAt, maxResults)
+		resp, err := ua.GetJSON(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == 200 {
+			results := &changelogPage{}
+			err := json.NewDecoder(resp.Body).Decode(results)
+			if err != nil {
+				return nil, err
+			}
+			if len(results.Values) == 0 {
+				break
+			}
+			startAt = startAt + len(results.Values)
+			total = results.Total
+			histories = append(histories, results.Values...)
+		} else {
+			return nil, responseError(resp)
+		}
+	}
+	return histories, nil
+}
+
 func (j *Jira) GetIssueComment(issue string) (*jiradata.Comments, error) {
 	return GetIssueComment(j.UA, j.Endpoint, issue)
 }
@@ -473,6 +520,26 @@ func RankIssues(ua HttpClient, endpoint string, rrp RankRequestProvider) error {
 	return responseError(resp)
 }
 
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-getIssueWatchers
+func (j *Jira) GetIssueWatchers(issue string) (*jiradata.Watchers, error) {
+	return GetIssueWatchers(j.UA, j.Endpoint, issue)
+}
+
+func GetIssueWatchers(ua HttpClient, endpoint string, issue string) (*jiradata.Watchers, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "watchers")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Watchers{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-addWatcher
 func (j *Jira) IssueAddWatcher(issue, user string) error {
 	return IssueAddWatcher(j.UA, j.Endpoint, issue, user)
@@ -0,0 +1,49 @@
+package jiracli
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/go-jira/jira"
+)
+
+// issueCacheTTL is the longest the editmeta and transitions for an issue are
+// cached, they change when the issue is modified outside of jira
+const issueCacheTTL = 5 * time.Minute
+
+// CacheDir is where the cached metadata and completions are stored
+func CacheDir() string {
+	return filepath.Join(Homedir(), ".jira.d", "cache")
+}
+
+// MetaCache returns the on disk cache for the metadata responses of the
+// login using the `cache-ttl` option.
+func MetaCache(globals *GlobalOptions) *jira.Cache {
+	ttl, err := time.ParseDuration(globals.CacheTTL.Value)
+	if err != nil {
+		log.Debugf("Invalid cache-ttl %q: %s", globals.CacheTTL.Value, err)
+		ttl = 0
+	}
+	issueTTL := issueCacheTTL
+	if ttl < issueTTL {
+		issueTTL = ttl
+	}
+	return &jira.Cache{
+		Dir:      filepath.Join(CacheDir(), "meta"),
+		TTL:      ttl,
+		IssueTTL: issueTTL,
+		Login:    globals.Login.Value,
+	}
+}
+
+// CacheClient will wrap the client so the metadata requests are served from
+// the MetaCache.
+func CacheClient(ua jira.HttpClient, globals *GlobalOptions) jira.HttpClient {
+	return jira.NewCacheClient(ua, MetaCache(globals))
+}
+
+// InvalidateIssueCache will discard the cached editmeta and transitions for
+// the issue after it was changed with a client that is not a CacheClient.
+func InvalidateIssueCache(globals *GlobalOptions, issue string) {
+	MetaCache(globals).Invalidate(issue)
+}
@@ -17,12 +17,12 @@ import (
 
 	"github.com/coryb/figtree"
 	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
 	"github.com/jinzhu/copier"
 	shellquote "github.com/kballard/go-shellquote"
 	"github.com/tidwall/gjson"
 	"gopkg.in/AlecAivazis/survey.v1"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
-	yaml "gopkg.in/coryb/yaml.v2"
 	logging "gopkg.in/op/go-logging.v1"
 )
 
@@ -61,6 +61,15 @@ type GlobalOptions struct {
 	// will promt for user password and use the /auth/1/session-login endpoint.
 	AuthenticationMethod figtree.StringOption `yaml:"authentication-method,omitempty" json:"authentication-method,omitempty"`
 
+	// CacheTTL is how long metadata like the fields, createmeta, editmeta and serverInfo responses are cached in
+	// ~/.jira.d/cache, something like "24h".  Transitions and editmeta are never cached for more than 5 minutes.
+	// Use "0" to disable the cache.
+	CacheTTL figtree.StringOption `yaml:"cache-ttl,omitempty" json:"cache-ttl,omitempty"`
+
+	// DefaultQuery is the JQL used to find the issues to pick from when a command is run interactively without
+	// the ISSUE argument.  The default is the unresolved issues assigned to the current user.
+	DefaultQuery figtree.StringOption `yaml:"default-query,omitempty" json:"default-query,omitempty"`
+
 	// Endpoint is the URL for the Jira service.  Something like: https://go-jira.atlassian.net
 	Endpoint figtree.StringOption `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
 
@@ -72,6 +81,10 @@ type GlobalOptions struct {
 	// like "user", which by default will use the same value in the `User` field.
 	Login figtree.StringOption `yaml:"login,omitempty" json:"login,omitempty"`
 
+	// Offline will use the issues mirrored with `jira sync` instead of connecting to the Jira service.  Comments,
+	// worklogs, transitions and edits are queued until `jira sync --push` is run.
+	Offline figtree.BoolOption `yaml:"offline,omitempty" json:"offline,omitempty"`
+
 	// PasswordSource specificies the method that we fetch the password.  Possible values are "keyring" or "pass".
 	// If this is unset we will just prompt the user.  For "keyring" this will look in the OS keychain, if missing
 	// then prompt the user and store the password in the OS keychain.  For "pass" this will look in the PasswordDirectory
@@ -112,6 +125,18 @@ type GlobalOptions struct {
 	// JiraDeploymentType can be `cloud` or `server`, if not set it will be inferred from
 	// the /rest/api/2/serverInfo REST API.
 	JiraDeploymentType figtree.StringOption `yaml:"jira-deployment-type,omitempty" json:"jira-deployment-type,omitempty"`
+
+	// Color can be `auto`, `always` or `never`.  With `auto` colors are only used when the output is a terminal
+	// and the NO_COLOR environment variable is not set.
+	Color figtree.StringOption `yaml:"color,omitempty" json:"color,omitempty"`
+
+	// Hyperlinks can be set to false to stop issue keys and links being printed as terminal hyperlinks when
+	// colors are used.
+	Hyperlinks figtree.BoolOption `yaml:"hyperlinks,omitempty" json:"hyperlinks,omitempty"`
+
+	// Theme overrides the colors used for statuses, priorities and issue types, like `status.done: green` or
+	// `priority.highest: red+b`.  Statuses can be named or use the category: `new`, `indeterminate` or `done`.
+	Theme map[string]string `yaml:"theme,omitempty" json:"theme,omitempty"`
 }
 
 type CommonOptions struct {
@@ -163,6 +188,9 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 	globals := GlobalOptions{
 		User:                 figtree.NewStringOption(os.Getenv("USER")),
 		AuthenticationMethod: figtree.NewStringOption("session"),
+		CacheTTL:             figtree.NewStringOption("24h"),
+		Color:                figtree.NewStringOption("auto"),
+		Hyperlinks:           figtree.NewBoolOption(true),
 	}
 	app.Flag("endpoint", "Base URI to use for Jira").Short('e').SetValue(&globals.Endpoint)
 	app.Flag("insecure", "Disable TLS certificate verification").Short('k').SetValue(&globals.Insecure)
@@ -171,8 +199,17 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 	app.Flag("socksproxy", "Address for a socks proxy").SetValue(&globals.SocksProxy)
 	app.Flag("user", "user name used within the Jira service").Short('u').SetValue(&globals.User)
 	app.Flag("login", "login name that corresponds to the user used for authentication").SetValue(&globals.Login)
+	app.Flag("offline", "Use the issues mirrored with `jira sync`").SetValue(&globals.Offline)
+	app.Flag("color", "When to use colors: auto, always or never").SetValue(&globals.Color)
+
+	// offline is set when the client is using the OfflineTransport, we do not
+	// need to authenticate then
+	offline := false
 
 	o = o.WithPreCallback(func(req *http.Request) (*http.Request, error) {
+		if offline {
+			return req, nil
+		}
 		if globals.AuthMethod() == "api-token" {
 			// need to set basic auth header with user@domain:api-token
 			token := globals.GetPass()
@@ -187,6 +224,10 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 	})
 
 	o = o.WithPostCallback(func(req *http.Request, resp *http.Response) (*http.Response, error) {
+		if completing || offline {
+			// never prompt for credentials while completing or offline
+			return resp, nil
+		}
 		if globals.AuthMethod() == "session" {
 			authUser := resp.Header.Get("X-Ausername")
 			if authUser == "" || authUser == "anonymous" {
@@ -210,6 +251,21 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 		return resp, nil
 	})
 
+	// the command PreActions are not run when completing, so the hints need
+	// to load the configs and prepare the client themselves
+	var completionConfig CompletionConfig
+	var completionReady bool
+	completionSetup = func() (*oreo.Client, *GlobalOptions, *CompletionConfig) {
+		if !completionReady {
+			fig.LoadAllConfigs("config.yml", &globals)
+			fig.LoadAllConfigs("list.yml", &completionConfig)
+			fig.LoadAllConfigs("config.yml", &completionConfig)
+			o = prepareClient(o, &globals)
+			completionReady = true
+		}
+		return o, &globals, &completionConfig
+	}
+
 	for _, command := range globalCommandRegistry {
 		copy := command
 		commandFields := strings.Fields(copy.Command)
@@ -227,26 +283,16 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 		cmd := appOrCmd.Command(commandFields[len(commandFields)-1], copy.Entry.Help)
 		LoadConfigs(cmd, fig, &globals)
 		cmd.PreAction(func(_ *kingpin.ParseContext) error {
-			if globals.Insecure.Value {
-				transport := &http.Transport{
-					Proxy: http.ProxyFromEnvironment,
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: true,
-					},
-				}
-				o = o.WithTransport(transport)
-			}
-			if globals.UnixProxy.Value != "" {
-				o = o.WithTransport(unixProxy(globals.UnixProxy.Value))
-			} else if globals.SocksProxy.Value != "" {
-				o = o.WithTransport(socksProxy(globals.SocksProxy.Value))
+			if err := ConfigureTerminal(&globals); err != nil {
+				return err
 			}
-			if globals.AuthMethodIsToken() {
-				o = o.WithCookieFile("")
-			}
-			if globals.Login.Value == "" {
-				globals.Login = globals.User
+			o = prepareClient(o, &globals)
+			// sync needs to connect to Jira to update the offline issues
+			if globals.Offline.Value && cmd.FullCommand() != "sync" {
+				o = o.WithTransport(&jira.OfflineTransport{Store: OfflineStore(&globals)})
+				offline = true
 			}
+			ConfigureFields(o, &globals)
 			return nil
 		})
 
@@ -264,11 +310,40 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 			if logging.GetLevel("") > logging.DEBUG {
 				o = o.WithTrace(true)
 			}
+			if err := pickIssueArg(cmd, o, &globals); err != nil {
+				return err
+			}
 			return copy.Entry.ExecuteFunc(o, &globals)
 		})
 	}
 }
 
+// prepareClient will configure the transport and authentication for the
+// client from the global options.
+func prepareClient(o *oreo.Client, globals *GlobalOptions) *oreo.Client {
+	if globals.Insecure.Value {
+		transport := &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			},
+		}
+		o = o.WithTransport(transport)
+	}
+	if globals.UnixProxy.Value != "" {
+		o = o.WithTransport(unixProxy(globals.UnixProxy.Value))
+	} else if globals.SocksProxy.Value != "" {
+		o = o.WithTransport(socksProxy(globals.SocksProxy.Value))
+	}
+	if globals.AuthMethodIsToken() {
+		o = o.WithCookieFile("")
+	}
+	if globals.Login.Value == "" {
+		globals.Login = globals.User
+	}
+	return o
+}
+
 func LoadConfigs(cmd *kingpin.CmdClause, fig *figtree.FigTree, opts interface{}) {
 	cmd.PreAction(func(_ *kingpin.ParseContext) error {
 		os.Setenv("JIRA_OPERATION", cmd.FullCommand())
@@ -431,45 +506,13 @@ func EditLoop(opts *CommonOptions, input interface{}, output interface{}, submit
 			return err
 		}
 
-		defer func(mapType, iface reflect.Type) {
-			yaml.DefaultMapType = mapType
-			yaml.IfaceType = iface
-		}(yaml.DefaultMapType, yaml.IfaceType)
-		yaml.DefaultMapType = reflect.TypeOf(map[string]interface{}{})
-		yaml.IfaceType = yaml.DefaultMapType.Elem()
-
 		// restore output incase of retry loop
 		err = copier.Copy(output, dup.Interface())
 		if err != nil {
 			return err
 		}
 
-		// HACK HACK HACK we want to trim out all the yaml garbage that is not
-		// poplulated, like empty arrays, string values with only a newline,
-		// etc.  We need to do this because jira will reject json documents
-		// with empty arrays, or empty strings typically.  So here we process
-		// the data to a raw interface{} then we fixup the yaml parsed
-		// interface, then we serialize to a new yaml document ... then is
-		// parsed as the original document to populate the output struct.  Phew.
-		var raw interface{}
-		if err := yaml.Unmarshal(data, &raw); err != nil {
-			log.Error(err.Error())
-			if confirm(true, "Invalid YAML syntax, edit again?") {
-				continue
-			}
-			return EditLoopAbort
-		}
-		yamlFixup(&raw)
-		fixedYAML, err := yaml.Marshal(&raw)
-		if err != nil {
-			log.Error(err.Error())
-			if confirm(true, "Invalid YAML syntax, edit again?") {
-				continue
-			}
-			return EditLoopAbort
-		}
-
-		if err := yaml.Unmarshal(fixedYAML, output); err != nil {
+		if err := ParseEditYAML(data, output); err != nil {
 			log.Error(err.Error())
 			if confirm(true, "Invalid YAML syntax, edit again?") {
 				continue
@@ -478,6 +521,17 @@ func EditLoop(opts *CommonOptions, input interface{}, output interface{}, submit
 		}
 		// submit template
 		if err := submit(); err != nil {
+			if _, ok := err.(FieldErrors); ok {
+				if opts.SkipEditing.Value {
+					// the document will not change, so fail right away
+					return CliError(err)
+				}
+				log.Error(err.Error())
+				if confirm(true, "Invalid fields, edit again?") {
+					continue
+				}
+				return EditLoopAbort
+			}
 			log.Error(err.Error())
 			if confirm(true, "Jira reported an error, edit again?") {
 				continue
@@ -514,47 +568,22 @@ func ReadYmlInputFile(opts *CommonOptions, input interface{}, output interface{}
 		return err
 	}
 
-	defer func(mapType, iface reflect.Type) {
-		yaml.DefaultMapType = mapType
-		yaml.IfaceType = iface
-	}(yaml.DefaultMapType, yaml.IfaceType)
-	yaml.DefaultMapType = reflect.TypeOf(map[string]interface{}{})
-	yaml.IfaceType = yaml.DefaultMapType.Elem()
-
 	// restore output incase of retry loop
 	err = copier.Copy(output, dup.Interface())
 	if err != nil {
 		return err
 	}
 
-	// HACK HACK HACK we want to trim out all the yaml garbage that is not
-	// poplulated, like empty arrays, string values with only a newline,
-	// etc.  We need to do this because jira will reject json documents
-	// with empty arrays, or empty strings typically.  So here we process
-	// the data to a raw interface{} then we fixup the yaml parsed
-	// interface, then we serialize to a new yaml document ... then is
-	// parsed as the original document to populate the output struct.  Phew.
-	var raw interface{}
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		log.Error(err.Error())
-		fmt.Printf("Invalid YAML syntax\n")
-		return FileAbort
-	}
-	yamlFixup(&raw)
-	fixedYAML, err := yaml.Marshal(&raw)
-	if err != nil {
-		log.Error(err.Error())
-		fmt.Printf("Invalid YAML syntax\n")
-		return FileAbort
-	}
-
-	if err := yaml.Unmarshal(fixedYAML, output); err != nil {
+	if err := ParseEditYAML(data, output); err != nil {
 		log.Error(err.Error())
 		fmt.Printf("Invalid YAML syntax\n")
 		return FileAbort
 	}
 	// submit template
 	if err := submit(); err != nil {
+		if _, ok := err.(FieldErrors); ok {
+			return CliError(err)
+		}
 		log.Error(err.Error())
 		fmt.Printf("Jira reported an error\n")
 		return FileAbort
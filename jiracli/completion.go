@@ -0,0 +1,118 @@
+package jiracli
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// completing is set when the shell is requesting completions, we must never
+// prompt for passwords or run the login command while completing.
+var completing bool
+
+// completionSetup will load the configuration and return the client for the
+// completion hints, it is set by register.
+var completionSetup func() (*oreo.Client, *GlobalOptions, *CompletionConfig)
+
+// completionCacheTTL is how long dynamic completions are cached on disk
+const completionCacheTTL = 2 * time.Minute
+
+// CompletionConfig holds the configuration used by the completions that is
+// not part of the GlobalOptions.  During completion the command specific
+// configs are not loaded, so this is read from config.yml and list.yml.
+type CompletionConfig struct {
+	Project string            `yaml:"project,omitempty" json:"project,omitempty"`
+	Queries map[string]string `yaml:"queries,omitempty" json:"queries,omitempty"`
+}
+
+// Completer returns the suggestions for a dynamic value like an issue key or
+// transition name.
+type Completer func(o *oreo.Client, globals *GlobalOptions, config *CompletionConfig) ([]string, error)
+
+// CompletionHint will wrap the completer as a kingpin HintAction.  The
+// completer is only run when the shell requests completions, and errors are
+// ignored so the shell just gets no suggestions.
+func CompletionHint(completer Completer) kingpin.HintAction {
+	return func() []string {
+		if !completing || completionSetup == nil {
+			return nil
+		}
+		results, err := completer(completionSetup())
+		if err != nil {
+			log.Debugf("Completion failed: %s", err)
+			return nil
+		}
+		return results
+	}
+}
+
+// IssueHint will suggest the keys of the issues found with the
+// `default-query`.
+func IssueHint() kingpin.HintAction {
+	return CompletionHint(IssueCompletions)
+}
+
+// IssueCompletions returns the issue keys found with the `default-query`.
+func IssueCompletions(o *oreo.Client, globals *GlobalOptions, config *CompletionConfig) ([]string, error) {
+	query := globals.DefaultQuery.Value
+	if query == "" {
+		query = DefaultIssueQuery
+	}
+	return CachedCompletions(globals, "issues:"+query, func() ([]string, error) {
+		results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query:      query,
+			MaxResults: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys := []string{}
+		for _, issue := range results.Issues {
+			keys = append(keys, issue.Key)
+		}
+		return keys, nil
+	})
+}
+
+// CachedCompletions will return the completions cached for the key if they
+// are recent, otherwise the completions are fetched and cached.  The cache
+// is kept in ~/.jira.d/cache/completion and is specific to the endpoint.
+func CachedCompletions(globals *GlobalOptions, key string, fetch func() ([]string, error)) ([]string, error) {
+	cacheFile := filepath.Join(
+		CacheDir(), "completion",
+		fmt.Sprintf("%x", sha1.Sum([]byte(globals.Endpoint.Value+"\x00"+key))),
+	)
+
+	if stat, err := os.Stat(cacheFile); err == nil && time.Since(stat.ModTime()) < completionCacheTTL {
+		if content, err := ioutil.ReadFile(cacheFile); err == nil {
+			results := []string{}
+			if err := json.Unmarshal(content, &results); err == nil {
+				return results, nil
+			}
+		}
+	}
+
+	results, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0755); err != nil {
+		log.Debugf("Unable to create completion cache directory: %s", err)
+		return results, nil
+	}
+	if content, err := json.Marshal(results); err == nil {
+		if err := ioutil.WriteFile(cacheFile, content, 0600); err != nil {
+			log.Debugf("Unable to write completion cache: %s", err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,237 @@
+package jiracli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiradata"
+)
+
+// The client used to look up the field names, set by ConfigureFields before
+// the command runs.  The fields are only fetched the first time a name needs
+// to be resolved.
+var (
+	fieldsClient   jira.HttpClient
+	fieldsEndpoint = ""
+	fieldsLoaded   []jiradata.Field
+	fieldIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// ConfigureFields sets up the client used to resolve the field names, the
+// fields are read through the metadata cache.
+func ConfigureFields(ua jira.HttpClient, globals *GlobalOptions) {
+	fieldsClient = CacheClient(ua, globals)
+	fieldsEndpoint = globals.Endpoint.Value
+	fieldsLoaded = nil
+}
+
+func fieldList() ([]jiradata.Field, error) {
+	if fieldsLoaded == nil && fieldsClient != nil {
+		fields, err := jira.GetFields(fieldsClient, fieldsEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		fieldsLoaded = fields
+	}
+	return fieldsLoaded, nil
+}
+
+// FieldID returns the id for a field name like "Story Points", names are
+// matched ignoring case.  Field ids and names that are not fields are
+// returned unchanged.  It is an error when more than one field has the name.
+func FieldID(name string) (string, error) {
+	return fieldID(name, nil)
+}
+
+// FieldIDs resolves a comma separated list of field names, like the
+// --queryfields option.  The "*all" and "-field" forms are kept.
+func FieldIDs(names string) (string, error) {
+	if names == "" {
+		return "", nil
+	}
+	ids := []string{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		prefix := ""
+		if strings.HasPrefix(name, "-") {
+			prefix, name = "-", name[1:]
+		}
+		if !strings.HasPrefix(name, "*") {
+			id, err := FieldID(name)
+			if err != nil {
+				return "", err
+			}
+			name = id
+		}
+		ids = append(ids, prefix+name)
+	}
+	return strings.Join(ids, ","), nil
+}
+
+// fieldID resolves the name, when known is not nil only the fields in it
+// are matched, by their id or by the name in the map.
+func fieldID(name string, known map[string]string) (string, error) {
+	if _, ok := known[name]; ok {
+		return name, nil
+	}
+	// without the field list we can still use the names in known
+	fields, err := fieldList()
+	if err != nil && known == nil {
+		if fieldIDPattern.MatchString(name) {
+			return name, nil
+		}
+		return "", err
+	}
+
+	names := map[string]string{}
+	for _, field := range fields {
+		if field.ID == name && known == nil {
+			return name, nil
+		}
+		names[field.ID] = field.Name
+	}
+	if known != nil {
+		for id, knownName := range known {
+			if knownName == "" {
+				knownName = names[id]
+			}
+			names[id] = knownName
+		}
+	}
+
+	ids := []string{}
+	for id, fieldName := range names {
+		if _, ok := known[id]; (ok || known == nil) && strings.EqualFold(fieldName, name) {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return name, nil
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("The field name %q is used by %s, use the field id instead", name, strings.Join(ids, ", "))
+}
+
+// fieldMetaNames returns the field ids and names from the create or edit
+// metadata, to resolve the names for the fields on the screen.
+func fieldMetaNames(meta jiradata.FieldMetaMap) map[string]string {
+	if len(meta) == 0 {
+		return nil
+	}
+	known := map[string]string{}
+	for id, field := range meta {
+		known[id] = ""
+		if field != nil {
+			known[id] = field.Name
+		}
+	}
+	return known
+}
+
+// ResolveIssueUpdate replaces the field names used in the fields and update
+// sections of the document with the field ids.  When the metadata is given
+// the names are matched with the fields in it first, the overrides named
+// after a custom field in it (like -o "Story Points=3") are set when the
+// document did not set the field, and the simple values are turned into the
+// JSON for the field schema (see coerceFieldValue).
+func ResolveIssueUpdate(update *jiradata.IssueUpdate, meta jiradata.FieldMetaMap, overrides map[string]string) error {
+	known := fieldMetaNames(meta)
+	resolve := func(name string) (string, error) {
+		id, err := fieldID(name, known)
+		if err == nil && id == name && known != nil {
+			// the field is not in the metadata, so try all the fields
+			return fieldID(name, nil)
+		}
+		return id, err
+	}
+	for name, value := range update.Fields {
+		id, err := resolve(name)
+		if err != nil {
+			return err
+		}
+		if id != name {
+			update.Fields[id] = value
+			delete(update.Fields, name)
+		}
+	}
+	for name, ops := range update.Update {
+		id, err := resolve(name)
+		if err != nil {
+			return err
+		}
+		if id != name {
+			update.Update[id] = ops
+			delete(update.Update, name)
+		}
+	}
+
+	if known == nil {
+		return nil
+	}
+	keys := []string{}
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		id, err := fieldID(key, known)
+		if err != nil {
+			return err
+		}
+		if _, ok := known[id]; !ok || !strings.HasPrefix(id, "customfield_") {
+			continue
+		}
+		if _, ok := update.Fields[id]; ok {
+			continue
+		}
+		if _, ok := update.Update[id]; ok {
+			continue
+		}
+		if update.Fields == nil {
+			update.Fields = map[string]interface{}{}
+		}
+		update.Fields[id] = overrides[key]
+	}
+
+	errs := FieldErrors{}
+	for id, value := range update.Fields {
+		coerced, err := coerceFieldValue(meta[id], value)
+		if err != nil {
+			errs[id] = err.Error()
+			continue
+		}
+		update.Fields[id] = coerced
+	}
+	for id, ops := range update.Update {
+		if err := coerceFieldOperations(meta[id], ops); err != nil {
+			errs[id] = err.Error()
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// templateFieldID is the "field" template function, the fields can be the
+// create or edit metadata fields or the issue fields to only match the
+// fields in them.
+func templateFieldID(name string, fields ...map[string]interface{}) (string, error) {
+	if len(fields) == 0 {
+		return FieldID(name)
+	}
+	known := map[string]string{}
+	for id, value := range fields[0] {
+		known[id] = ""
+		if meta, ok := value.(map[string]interface{}); ok {
+			known[id], _ = meta["name"].(string)
+		}
+	}
+	return fieldID(name, known)
+}
@@ -0,0 +1,82 @@
+package jiracli
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveIssueUpdate(t *testing.T) {
+	defer func(fields []jiradata.Field) { fieldsLoaded = fields }(fieldsLoaded)
+	fieldsLoaded = []jiradata.Field{
+		{ID: "summary", Name: "Summary"},
+		{ID: "watches", Name: "Watchers"},
+		{ID: "customfield_10010", Name: "Story Points", Custom: true},
+		{ID: "customfield_10030", Name: "Team", Custom: true},
+		{ID: "customfield_10031", Name: "Team", Custom: true},
+		{ID: "customfield_10110", Name: "Watchers", Custom: true},
+	}
+
+	id, err := FieldID("story points")
+	assert.NoError(t, err)
+	assert.Equal(t, "customfield_10010", id)
+
+	ids, err := FieldIDs("summary,Story Points,-labels,*navigable")
+	assert.NoError(t, err)
+	assert.Equal(t, "summary,customfield_10010,-labels,*navigable", ids)
+
+	_, err = FieldID("Team")
+	assert.EqualError(t, err, `The field name "Team" is used by customfield_10030, customfield_10031, use the field id instead`)
+
+	// the fields on the screen pick the field when the name is used twice
+	meta := jiradata.FieldMetaMap{
+		"summary":           {Name: "Summary"},
+		"customfield_10010": {Name: "Story Points", Schema: &jiradata.JSONType{Type: "number"}},
+		"customfield_10030": {Name: "Team", Schema: &jiradata.JSONType{Type: "string"}},
+		"customfield_10110": {Name: "Watchers"},
+	}
+	update := &jiradata.IssueUpdate{
+		Fields: map[string]interface{}{"Summary": "Fix it", "Watchers": []interface{}{}},
+	}
+	overrides := map[string]string{"summary": "ignored", "team": "Red", "story points": "3", "comment": "hi"}
+	assert.NoError(t, ResolveIssueUpdate(update, meta, overrides))
+	assert.Equal(t, map[string]interface{}{
+		"summary":           "Fix it",
+		"customfield_10010": 3.0,
+		"customfield_10030": "Red",
+		"customfield_10110": []interface{}{},
+	}, update.Fields)
+}
+
+func TestCoerceFieldValue(t *testing.T) {
+	priority := &jiradata.FieldMeta{
+		Name:          "Priority",
+		Schema:        &jiradata.JSONType{Type: "priority"},
+		AllowedValues: []interface{}{map[string]interface{}{"id": "1", "name": "Major"}, map[string]interface{}{"id": "2", "name": "Minor"}},
+	}
+	value, err := coerceFieldValue(priority, "major")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "Major"}, value)
+
+	_, err = coerceFieldValue(priority, "Blocker")
+	assert.EqualError(t, err, `Invalid value "Blocker" for Priority, allowed values: Major, Minor`)
+
+	components := &jiradata.FieldMeta{Name: "Components", Schema: &jiradata.JSONType{Type: "array", Items: "component"}}
+	value, err = coerceFieldValue(components, "web, api")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "web"}, map[string]interface{}{"name": "api"}}, value)
+
+	option := &jiradata.FieldMeta{Name: "Team", Schema: &jiradata.JSONType{Type: "option"}}
+	value, err = coerceFieldValue(option, "Red")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"value": "Red"}, value)
+
+	points := &jiradata.FieldMeta{Name: "Story Points", Schema: &jiradata.JSONType{Type: "number"}}
+	_, err = coerceFieldValue(points, "lots")
+	assert.EqualError(t, err, `Invalid number "lots" for Story Points`)
+
+	ops := jiradata.FieldOperations{{"add": "ui"}}
+	assert.NoError(t, coerceFieldOperations(components, ops))
+	assert.Equal(t, map[string]interface{}{"name": "ui"}, ops[0]["add"])
+}
@@ -0,0 +1,188 @@
+package jiracli
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// accountIDPattern matches the Atlassian Cloud account ids, the old 24
+// character ids and the newer "557058:uuid" ids.
+var accountIDPattern = regexp.MustCompile(`^([0-9a-f]{24}|[0-9]+:[0-9a-f-]{36})$`)
+
+// fieldValueProperty returns the property Jira expects for a value of the
+// schema type, like "name" for {"name": "Major"}.  An empty string means the
+// value is sent as it is.
+func fieldValueProperty(schemaType string) string {
+	switch schemaType {
+	case "option":
+		return "value"
+	case "user", "group", "priority", "version", "component", "resolution", "issuetype", "securitylevel":
+		return "name"
+	case "project":
+		return "key"
+	}
+	return ""
+}
+
+// coerceFieldValue builds the JSON for the field from a simple value, so
+// "Major" becomes {"name": "Major"} for a priority and "web, api" becomes
+// [{"name": "web"}, {"name": "api"}] for the components.  Values that are
+// already objects are left alone.  When the field has allowed values the
+// value must be one of them.
+func coerceFieldValue(meta *jiradata.FieldMeta, value interface{}) (interface{}, error) {
+	if meta == nil || meta.Schema == nil || value == nil {
+		return value, nil
+	}
+	if meta.Schema.Type != "array" {
+		return coerceFieldItem(meta, meta.Schema.Type, value)
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		if _, ok := value.(map[string]interface{}); ok {
+			return value, nil
+		}
+		// a comma separated list, like from an override
+		items = []interface{}{}
+		for _, item := range strings.Split(fmt.Sprint(value), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	coerced := []interface{}{}
+	for _, item := range items {
+		item, err := coerceFieldItem(meta, meta.Schema.Items, item)
+		if err != nil {
+			return nil, err
+		}
+		coerced = append(coerced, item)
+	}
+	return coerced, nil
+}
+
+func coerceFieldItem(meta *jiradata.FieldMeta, schemaType string, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		return coerceFieldObject(meta, schemaType, object)
+	}
+	if _, ok := value.([]interface{}); ok {
+		return value, nil
+	}
+	text := strings.TrimSpace(fmt.Sprint(value))
+
+	switch schemaType {
+	case "string":
+		return fmt.Sprint(value), nil
+	case "number":
+		switch value.(type) {
+		case int, int64, float64:
+			return value, nil
+		}
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid number %q for %s", text, meta.Name)
+		}
+		return number, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, fmt.Errorf("Invalid date %q for %s, use a date like 2006-01-02", text, meta.Name)
+		}
+		return text, nil
+	case "datetime":
+		if _, err := time.Parse("2006-01-02T15:04:05.000-0700", text); err == nil {
+			return text, nil
+		}
+		for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+			if when, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+				return when.Format("2006-01-02T15:04:05.000-0700"), nil
+			}
+		}
+		return nil, fmt.Errorf("Invalid time %q for %s, use a time like \"2006-01-02 15:04\"", text, meta.Name)
+	}
+
+	property := fieldValueProperty(schemaType)
+	if property == "" {
+		return value, nil
+	}
+	if schemaType == "user" && accountIDPattern.MatchString(text) {
+		return map[string]interface{}{"accountId": text}, nil
+	}
+	if len(meta.AllowedValues) > 0 {
+		allowed, err := allowedFieldValue(meta, property, text)
+		if err != nil {
+			return nil, err
+		}
+		text = allowed
+	}
+	return map[string]interface{}{property: text}, nil
+}
+
+// coerceFieldObject checks the objects with just the property, like the
+// {"name": "Major"} from the templates, against the allowed values.
+func coerceFieldObject(meta *jiradata.FieldMeta, schemaType string, object map[string]interface{}) (interface{}, error) {
+	property := fieldValueProperty(schemaType)
+	text, ok := object[property].(string)
+	if property == "" || len(object) != 1 || !ok || text == "" || len(meta.AllowedValues) == 0 {
+		return object, nil
+	}
+	allowed, err := allowedFieldValue(meta, property, text)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{property: allowed}, nil
+}
+
+// allowedFieldValue returns the allowed value matching the text, by the
+// property or the id ignoring case, or an error listing the allowed values.
+func allowedFieldValue(meta *jiradata.FieldMeta, property, text string) (string, error) {
+	choices := []string{}
+	for _, value := range meta.AllowedValues {
+		allowed, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		choice, ok := allowed[property].(string)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(choice, text) || fmt.Sprint(allowed["id"]) == text {
+			return choice, nil
+		}
+		choices = append(choices, choice)
+	}
+	if len(choices) == 0 {
+		// the allowed values do not have the property, so we cannot check
+		return text, nil
+	}
+	return "", fmt.Errorf("Invalid value %q for %s, allowed values: %s", text, meta.Name, strings.Join(choices, ", "))
+}
+
+// coerceFieldOperations builds the JSON for the values in the update
+// operations, "add" and "remove" work on one item of the array fields.
+func coerceFieldOperations(meta *jiradata.FieldMeta, ops jiradata.FieldOperations) error {
+	if meta == nil || meta.Schema == nil {
+		return nil
+	}
+	for _, op := range ops {
+		for verb, value := range op {
+			var err error
+			if meta.Schema.Type == "array" && verb != "set" {
+				op[verb], err = coerceFieldItem(meta, meta.Schema.Items, value)
+			} else {
+				op[verb], err = coerceFieldValue(meta, value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
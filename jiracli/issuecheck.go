@@ -0,0 +1,168 @@
+package jiracli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// FieldErrors are the problems found with the fields of an issue before it
+// is sent to Jira, the messages are by field like the errors from Jira.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := []string{}
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	messages := []string{}
+	for _, field := range fields {
+		messages = append(messages, field+": "+e[field])
+	}
+	return strings.Join(messages, "\n")
+}
+
+// screenFields can always be set when creating an issue, even when the
+// create metadata does not list them.
+var screenFields = map[string]bool{
+	"project":   true,
+	"issuetype": true,
+	"parent":    true,
+}
+
+// CheckIssueUpdate checks the resolved document against the create or edit
+// metadata before it is submitted: the fields must be on the screen, the
+// update operations must be allowed for the field, and for "create" the
+// required fields without a default must be set.  Misspelled field names get
+// a suggestion.  Nothing is checked without the metadata.
+func CheckIssueUpdate(update *jiradata.IssueUpdate, meta jiradata.FieldMetaMap, screen string) error {
+	if len(meta) == 0 {
+		return nil
+	}
+	errs := FieldErrors{}
+	check := func(id string, operations ...string) {
+		field, ok := meta[id]
+		if !ok {
+			if !screenFields[id] {
+				errs[id] = unknownFieldMessage(id, meta, screen)
+			}
+			return
+		}
+		if field == nil || len(field.Operations) == 0 {
+			return
+		}
+		for _, operation := range operations {
+			if !hasOperation(field.Operations, operation) {
+				errs[id] = fmt.Sprintf("Operation %q is not allowed for %s, allowed operations: %s", operation, field.Name, strings.Join(field.Operations, ", "))
+				return
+			}
+		}
+	}
+	for id := range update.Fields {
+		check(id, "set")
+	}
+	for id, ops := range update.Update {
+		operations := []string{}
+		for _, op := range ops {
+			for operation := range op {
+				operations = append(operations, operation)
+			}
+		}
+		sort.Strings(operations)
+		check(id, operations...)
+	}
+
+	if screen == "create" {
+		for id, field := range meta {
+			if field == nil || !field.Required || field.HasDefaultValue {
+				continue
+			}
+			if _, ok := update.Fields[id]; ok {
+				continue
+			}
+			if _, ok := update.Update[id]; ok {
+				continue
+			}
+			errs[id] = fmt.Sprintf("%s is required", field.Name)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func hasOperation(operations jiradata.Operations, operation string) bool {
+	for _, allowed := range operations {
+		if allowed == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownFieldMessage explains why the field cannot be set, suggesting the
+// field on the screen with the closest name for a misspelled field.
+func unknownFieldMessage(name string, meta jiradata.FieldMetaMap, screen string) string {
+	fields, _ := fieldList()
+	for _, field := range fields {
+		if field.ID == name {
+			return fmt.Sprintf("%s is not on the %s screen", field.Name, screen)
+		}
+	}
+
+	best, bestDistance := "", -1
+	for id, field := range meta {
+		candidates := []string{id}
+		if field != nil && field.Name != "" {
+			candidates = append(candidates, field.Name)
+		}
+		for _, candidate := range candidates {
+			distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+			if bestDistance < 0 || distance < bestDistance || distance == bestDistance && candidate < best {
+				best, bestDistance = candidate, distance
+			}
+		}
+	}
+	limit := len(name) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDistance >= 0 && bestDistance <= limit {
+		return fmt.Sprintf("Unknown field, did you mean %q?", best)
+	}
+	return "Unknown field"
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := range source {
+		current := make([]int, len(target)+1)
+		current[0] = i + 1
+		for j := range target {
+			cost := 1
+			if source[i] == target[j] {
+				cost = 0
+			}
+			current[j+1] = minInt(previous[j]+cost, minInt(previous[j+1]+1, current[j]+1))
+		}
+		previous = current
+	}
+	return previous[len(target)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+package jiracli
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckIssueUpdate(t *testing.T) {
+	defer func(fields []jiradata.Field) { fieldsLoaded = fields }(fieldsLoaded)
+	fieldsLoaded = []jiradata.Field{
+		{ID: "summary", Name: "Summary"},
+		{ID: "fixVersions", Name: "Fix Version/s"},
+		{ID: "customfield_10010", Name: "Story Points", Custom: true},
+	}
+
+	meta := jiradata.FieldMetaMap{
+		"summary":           {Name: "Summary", Required: true, Operations: jiradata.Operations{"set"}},
+		"reporter":          {Name: "Reporter", Required: true, HasDefaultValue: true},
+		"labels":            {Name: "Labels", Operations: jiradata.Operations{"add", "set", "remove"}},
+		"customfield_10010": {Name: "Story Points", Required: true},
+	}
+	update := &jiradata.IssueUpdate{
+		Fields: map[string]interface{}{
+			"project":      map[string]interface{}{"key": "TEST"},
+			"Story Pionts": 3,
+			"fixVersions":  []interface{}{},
+		},
+		Update: jiradata.FieldOperationsMap{
+			"summary": jiradata.FieldOperations{{"add": "x"}},
+			"labels":  jiradata.FieldOperations{{"add": "a"}},
+		},
+	}
+	err := CheckIssueUpdate(update, meta, "create")
+	assert.EqualError(t, err, `Story Pionts: Unknown field, did you mean "Story Points"?
+customfield_10010: Story Points is required
+fixVersions: Fix Version/s is not on the create screen
+summary: Operation "add" is not allowed for Summary, allowed operations: set`)
+
+	// the required fields are only checked when creating issues
+	update = &jiradata.IssueUpdate{
+		Fields: map[string]interface{}{"summary": "Fix it"},
+	}
+	assert.NoError(t, CheckIssueUpdate(update, meta, "edit"))
+	assert.NoError(t, CheckIssueUpdate(update, nil, "create"))
+}
@@ -0,0 +1,379 @@
+package jiracli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"golang.org/x/crypto/ssh/terminal"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// DefaultIssueQuery is used to populate the issue picker when the
+// `default-query` option has not been configured.
+const DefaultIssueQuery = "resolution = unresolved AND assignee = currentUser() ORDER BY updated DESC"
+
+// issueArgs tracks the ISSUE argument for each command using IssueArgUsage so
+// the issue picker can be run before the command is executed.
+var issueArgs = map[*kingpin.CmdClause]*issueArg{}
+
+// issueArg is the ISSUE argument of a command and the required arguments
+// after it, which are only checked once the issue has been picked.
+type issueArg struct {
+	issue     *string
+	issues    *[]string
+	optional  bool
+	skip      func() bool
+	following []followingArg
+}
+
+type followingArg struct {
+	name  string
+	value interface{} // *string or *[]string
+}
+
+// IssueArgUsage will add the ISSUE argument to the command.  The argument is
+// required unless we are running in a terminal, then if the argument is
+// omitted the user will be prompted to pick an issue from the results of the
+// `default-query` search.
+func IssueArgUsage(cmd *kingpin.CmdClause, help string, issue *string) {
+	arg := cmd.Arg("ISSUE", help).HintAction(IssueHint())
+	if !isInteractive() {
+		arg.Required()
+	}
+	arg.StringVar(issue)
+	issueArgs[cmd] = &issueArg{issue: issue}
+}
+
+// OptionalIssueArgUsage is IssueArgUsage for commands that also work without
+// an issue, like edit with a --query, so the argument is never required.  Use
+// SkipIssuePicker to tell when the issue is not needed.
+func OptionalIssueArgUsage(cmd *kingpin.CmdClause, help string, issue *string) {
+	cmd.Arg("ISSUE", help).HintAction(IssueHint()).StringVar(issue)
+	issueArgs[cmd] = &issueArg{issue: issue, optional: true}
+}
+
+// IssuesArgUsage is IssueArgUsage for commands taking a list of issues, the
+// picked issue is used when none are given.
+func IssuesArgUsage(cmd *kingpin.CmdClause, help string, issues *[]string) {
+	arg := cmd.Arg("ISSUE", help).HintAction(IssueHint())
+	if !isInteractive() {
+		arg.Required()
+	}
+	arg.StringsVar(issues)
+	issueArgs[cmd] = &issueArg{issues: issues}
+}
+
+// ArgAfterIssueUsage adds a required argument after the ISSUE argument, like
+// the LABEL in `labels add ISSUE LABEL...`, the value is a *string or a
+// *[]string.  In a terminal the ISSUE can be left out, then the values given
+// are moved to the following arguments, so `jira labels add urgent` adds the
+// label to the picked issue.  It must be called after IssueArgUsage.
+func ArgAfterIssueUsage(cmd *kingpin.CmdClause, name, help string, value interface{}) *kingpin.ArgClause {
+	arg := cmd.Arg(name, help)
+	if !isInteractive() {
+		arg.Required()
+	}
+	switch v := value.(type) {
+	case *string:
+		arg.StringVar(v)
+	case *[]string:
+		arg.StringsVar(v)
+	default:
+		panic(fmt.Sprintf("unsupported argument type %T for %s", value, name))
+	}
+	issueArgs[cmd].following = append(issueArgs[cmd].following, followingArg{name, value})
+	return arg
+}
+
+// SkipIssuePicker will not prompt for the ISSUE argument when skip returns
+// true, like when the issues are found with a --query instead.
+func SkipIssuePicker(cmd *kingpin.CmdClause, skip func() bool) {
+	issueArgs[cmd].skip = skip
+}
+
+func isInteractive() bool {
+	return terminal.IsTerminal(int(os.Stdin.Fd())) && terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// pickIssueArg will prompt for the ISSUE argument if the command uses
+// IssueArgUsage and the argument was not provided.
+func pickIssueArg(cmd *kingpin.CmdClause, o *oreo.Client, globals *GlobalOptions) error {
+	arg, ok := issueArgs[cmd]
+	if !ok || !isInteractive() {
+		return nil
+	}
+	if arg.skip != nil && arg.skip() || !arg.missing() {
+		return arg.check()
+	}
+	if name := arg.firstMissing(); name != "" {
+		// do not prompt when the command cannot run anyway
+		return CliError(fmt.Errorf("required argument '%s' not provided", name))
+	}
+	query := globals.DefaultQuery.Value
+	if query == "" {
+		query = DefaultIssueQuery
+	}
+	picked, err := PickIssue(o, globals.Endpoint.Value, query)
+	if err != nil {
+		return err
+	}
+	if arg.issues != nil {
+		*arg.issues = append(*arg.issues, picked)
+	} else {
+		*arg.issue = picked
+	}
+	return arg.check()
+}
+
+// missing reports whether the issue needs to be picked.  When the ISSUE was
+// left out the values given for it and the following arguments are moved to
+// the following arguments.
+func (a *issueArg) missing() bool {
+	if a.issues != nil {
+		return len(*a.issues) == 0
+	}
+	if *a.issue == "" {
+		return true
+	}
+	if a.firstMissing() == "" {
+		return false
+	}
+	values := []string{*a.issue}
+	for _, following := range a.following {
+		switch v := following.value.(type) {
+		case *string:
+			if *v != "" {
+				values = append(values, *v)
+			}
+		case *[]string:
+			values = append(values, *v...)
+		}
+	}
+	*a.issue = ""
+	for _, following := range a.following {
+		switch v := following.value.(type) {
+		case *string:
+			*v = ""
+			if len(values) > 0 {
+				*v, values = values[0], values[1:]
+			}
+		case *[]string:
+			*v, values = values, nil
+		}
+	}
+	return true
+}
+
+// firstMissing returns the name of the first argument after the ISSUE that
+// was not given.
+func (a *issueArg) firstMissing() string {
+	for _, following := range a.following {
+		switch v := following.value.(type) {
+		case *string:
+			if *v == "" {
+				return following.name
+			}
+		case *[]string:
+			if len(*v) == 0 {
+				return following.name
+			}
+		}
+	}
+	return ""
+}
+
+// check returns the same error kingpin reports for missing arguments, since
+// they are not required when running in a terminal.
+func (a *issueArg) check() error {
+	name := a.firstMissing()
+	if !a.optional && (a.issue != nil && *a.issue == "" || a.issues != nil && len(*a.issues) == 0) {
+		name = "ISSUE"
+	}
+	if name != "" {
+		return CliError(fmt.Errorf("required argument '%s' not provided", name))
+	}
+	return nil
+}
+
+// PickIssue will prompt the user to select one of the issues found with the
+// query.  Typing while the list is displayed will fuzzy filter the issues by
+// key, status or summary.
+func PickIssue(o *oreo.Client, endpoint string, query string) (string, error) {
+	results, err := jira.Search(o, endpoint, &jira.SearchOptions{
+		Query:       query,
+		QueryFields: "status",
+		MaxResults:  100,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(results.Issues) == 0 {
+		return "", CliError(fmt.Errorf("No issues found with query %q, the ISSUE argument is required", query))
+	}
+
+	choices := []string{}
+	for _, issue := range results.Issues {
+		status := ""
+		if s, ok := issue.Fields["status"].(map[string]interface{}); ok {
+			status, _ = s["name"].(string)
+		}
+		summary, _ := issue.Fields["summary"].(string)
+		choices = append(choices, fmt.Sprintf("%-12s [%s] %s", issue.Key, status, summary))
+	}
+
+	selected, err := fuzzySelect("Select issue:", choices)
+	if err != nil {
+		return "", err
+	}
+	return results.Issues[selected].Key, nil
+}
+
+// fuzzySelect will prompt the user to choose one of the choices.  Typing will
+// filter the choices to the ones containing the typed characters in order,
+// each space separated term is matched separately.
+func fuzzySelect(message string, choices []string) (int, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return 0, err
+	}
+	defer terminal.Restore(fd, state)
+
+	width, _, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width = 80
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	filter, selected, drawn := "", 0, 0
+	matches := fuzzyFilter(filter, choices)
+	clear := func() {
+		if drawn > 0 {
+			fmt.Fprintf(out, "\x1b[%dF", drawn)
+		} else {
+			fmt.Fprint(out, "\r")
+		}
+		fmt.Fprint(out, "\x1b[J")
+	}
+	fmt.Fprint(out, "\x1b[?25l")
+	defer func() {
+		fmt.Fprint(out, "\x1b[?25h")
+		out.Flush()
+	}()
+
+	buf := make([]byte, 64)
+	for {
+		clear()
+		fmt.Fprintf(out, "\x1b[1;92m?\x1b[0m \x1b[1m%s\x1b[0m %s", message, filter)
+		first := 0
+		if selected >= fuzzyPageSize {
+			first = selected - fuzzyPageSize + 1
+		}
+		drawn = 0
+		for i := first; i < len(matches) && i < first+fuzzyPageSize; i++ {
+			line := []rune(choices[matches[i]])
+			if len(line) > width-3 {
+				line = line[:width-3]
+			}
+			if i == selected {
+				fmt.Fprintf(out, "\r\n\x1b[36m> %s\x1b[0m", string(line))
+			} else {
+				fmt.Fprintf(out, "\r\n  %s", string(line))
+			}
+			drawn++
+		}
+		out.Flush()
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		switch key := string(buf[:n]); key {
+		case "\r", "\n":
+			if len(matches) == 0 {
+				continue
+			}
+			clear()
+			fmt.Fprintf(out, "\x1b[1;92m?\x1b[0m \x1b[1m%s\x1b[0m \x1b[36m%s\x1b[0m\r\n", message, choices[matches[selected]])
+			return matches[selected], nil
+		case "\x03", "\x1b":
+			clear()
+			return 0, CliError(fmt.Errorf("No issue selected"))
+		case "\x1b[A", "\x10":
+			if selected > 0 {
+				selected--
+			}
+		case "\x1b[B", "\x0e":
+			if selected < len(matches)-1 {
+				selected++
+			}
+		case "\x7f", "\x08":
+			if filter != "" {
+				runes := []rune(filter)
+				filter = string(runes[:len(runes)-1])
+				matches, selected = fuzzyFilter(filter, choices), 0
+			}
+		default:
+			if buf[0] >= ' ' && buf[0] != 0x7f {
+				filter += key
+				matches, selected = fuzzyFilter(filter, choices), 0
+			}
+		}
+	}
+}
+
+const fuzzyPageSize = 15
+
+// fuzzyFilter returns the indexes of the choices matching the filter, the
+// closest matches first.
+func fuzzyFilter(filter string, choices []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+	matches := []match{}
+	for i, choice := range choices {
+		if ok, score := fuzzyMatch(filter, choice); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+	indexes := []int{}
+	for _, m := range matches {
+		indexes = append(indexes, m.index)
+	}
+	return indexes
+}
+
+// fuzzyMatch reports whether every space separated term in the filter
+// appears in the choice as a case insensitive subsequence.  The score counts
+// the skipped characters, so lower scores are closer matches.
+func fuzzyMatch(filter, choice string) (bool, int) {
+	choice = strings.ToLower(choice)
+	score := 0
+	for _, term := range strings.Fields(strings.ToLower(filter)) {
+		pattern := []rune(term)
+		matched, last, termScore := 0, -1, 0
+		for i, r := range []rune(choice) {
+			if matched < len(pattern) && r == pattern[matched] {
+				if last >= 0 {
+					termScore += i - last - 1
+				}
+				last = i
+				matched++
+			}
+		}
+		if matched < len(pattern) {
+			return false, 0
+		}
+		score += termScore
+	}
+	return true, score
+}
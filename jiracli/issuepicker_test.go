@@ -0,0 +1,74 @@
+package jiracli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	choices := []string{
+		"TEST-1       [Open] Fix the login page",
+		"TEST-2       [Done] Login with SSO",
+		"TEST-12      [Open] Update the docs",
+		"OTHER-3      [Open] Flaky login test",
+	}
+
+	// no filter keeps the order of the search
+	assert.Equal(t, []int{0, 1, 2, 3}, fuzzyFilter("", choices))
+
+	// the closest matches come first, ties keep the search order
+	assert.Equal(t, []int{0, 1, 3}, fuzzyFilter("login", choices))
+	assert.Equal(t, []int{0, 2}, fuzzyFilter("test-1", choices))
+
+	// fewer skipped characters rank higher
+	assert.Equal(t, []int{1, 0, 2}, fuzzyFilter("abc", []string{"a-b-c", "abc", "axxbxxc", "cba"}))
+
+	// every term must match, ignoring case
+	assert.Equal(t, []int{0, 3}, fuzzyFilter("OPEN login", choices))
+	assert.Empty(t, fuzzyFilter("zzz", choices))
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	ok, exact := fuzzyMatch("login", "Fix the login page")
+	assert.True(t, ok)
+	assert.Equal(t, 0, exact)
+
+	ok, spread := fuzzyMatch("lgn", "Fix the login page")
+	assert.True(t, ok)
+	assert.Equal(t, 2, spread)
+
+	ok, _ = fuzzyMatch("nigol", "Fix the login page")
+	assert.False(t, ok)
+}
+
+func TestIssueArgMissing(t *testing.T) {
+	// `labels add urgent` leaves out the ISSUE
+	issue, labels := "urgent", []string{}
+	arg := &issueArg{issue: &issue, following: []followingArg{{"LABEL", &labels}}}
+	assert.True(t, arg.missing())
+	assert.Equal(t, "", issue)
+	assert.Equal(t, []string{"urgent"}, labels)
+
+	// `labels add TEST-1 urgent` has everything
+	issue, labels = "TEST-1", []string{"urgent"}
+	assert.False(t, arg.missing())
+	assert.NoError(t, arg.check())
+
+	// `property get` with neither the ISSUE nor the KEY
+	issue, key := "", ""
+	arg = &issueArg{issue: &issue, following: []followingArg{{"KEY", &key}}}
+	assert.True(t, arg.missing())
+	issue = "TEST-1"
+	assert.EqualError(t, arg.check(), "required argument 'KEY' not provided")
+
+	// edit can work without the issue
+	issue = ""
+	arg = &issueArg{issue: &issue, optional: true}
+	assert.NoError(t, arg.check())
+
+	issues := []string{}
+	arg = &issueArg{issues: &issues}
+	assert.True(t, arg.missing())
+	assert.EqualError(t, arg.check(), "required argument 'ISSUE' not provided")
+}
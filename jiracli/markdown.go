@@ -0,0 +1,188 @@
+package jiracli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// This converts the Jira wiki markup used for descriptions and comments to
+// Markdown.  It handles the common markup (headings, lists, code, quotes,
+// tables, links and text effects), anything else is passed through as is.
+
+var (
+	wikiHeading   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiList      = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiCodeStart = regexp.MustCompile(`^\s*\{(code|noformat)(?::([^}|]*)[^}]*)?\}(.*)$`)
+	wikiQuote     = regexp.MustCompile(`^\s*\{quote\}(.*)$`)
+	wikiPanel     = regexp.MustCompile(`\{panel(:[^}]*)?\}`)
+	wikiColor     = regexp.MustCompile(`\{color(:[^}]*)?\}`)
+	wikiMonospace = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiLink      = regexp.MustCompile(`\[([^\[\]|]+)\|([^\[\]]+)\]`)
+	wikiBareLink  = regexp.MustCompile(`\[((?:https?|mailto|ftp):[^\[\]|]+)\]`)
+	wikiMention   = regexp.MustCompile(`\[~(?:accountid:)?([^\]]+)\]`)
+	wikiImage     = regexp.MustCompile(`!([^\s!|]+)(\|[^!]*)?!`)
+)
+
+func wikiToMarkdown(content string) string {
+	out := []string{}
+	fence := ""
+	quote := false
+	tableHeader := false
+	for _, line := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n") {
+		if fence != "" {
+			if i := strings.Index(line, fence); i >= 0 {
+				if before := line[:i]; strings.TrimSpace(before) != "" {
+					out = append(out, before)
+				}
+				out = append(out, "```")
+				fence = ""
+				continue
+			}
+			out = append(out, line)
+			continue
+		}
+		if match := wikiCodeStart.FindStringSubmatch(line); match != nil {
+			fence = "{" + match[1] + "}"
+			language := strings.TrimSpace(match[2])
+			if strings.Contains(language, "=") {
+				// only options like {code:title=Foo.java}, no language
+				language = ""
+			}
+			out = append(out, "```"+language)
+			rest := match[3]
+			if i := strings.Index(rest, fence); i >= 0 {
+				// single line {code}foo{code}
+				out = append(out, rest[:i], "```")
+				fence = ""
+			} else if strings.TrimSpace(rest) != "" {
+				out = append(out, rest)
+			}
+			continue
+		}
+		if match := wikiQuote.FindStringSubmatch(line); match != nil {
+			quote = !quote
+			if rest := strings.Replace(match[1], "{quote}", "", -1); strings.TrimSpace(rest) != "" {
+				out = append(out, "> "+wikiInline(rest))
+			}
+			continue
+		}
+
+		prefix := ""
+		if quote {
+			prefix = "> "
+		}
+		line = wikiPanel.ReplaceAllString(line, "")
+		trimmed := strings.TrimSpace(line)
+
+		isTable := strings.HasPrefix(trimmed, "|")
+		if !isTable {
+			tableHeader = false
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "bq. "):
+			out = append(out, "> "+wikiInline(trimmed[4:]))
+		case trimmed == "----":
+			out = append(out, prefix+"---")
+		case wikiHeading.MatchString(trimmed):
+			match := wikiHeading.FindStringSubmatch(trimmed)
+			level := int(match[1][0] - '0')
+			out = append(out, prefix+strings.Repeat("#", level)+" "+wikiInline(match[2]))
+		case wikiList.MatchString(trimmed) && !strings.HasPrefix(trimmed, "----"):
+			match := wikiList.FindStringSubmatch(trimmed)
+			depth := len(match[1])
+			bullet := "-"
+			if strings.HasSuffix(match[1], "#") {
+				bullet = "1."
+			}
+			out = append(out, prefix+strings.Repeat("  ", depth-1)+bullet+" "+wikiInline(match[2]))
+		case isTable && strings.HasPrefix(trimmed, "||"):
+			cells := wikiTableCells(strings.Trim(trimmed, "|"), "||")
+			out = append(out, prefix+"| "+strings.Join(cells, " | ")+" |")
+			out = append(out, prefix+strings.TrimSuffix(strings.Repeat("| --- ", len(cells)), " ")+" |")
+			tableHeader = true
+		case isTable:
+			cells := wikiTableCells(strings.Trim(trimmed, "|"), "|")
+			if !tableHeader {
+				// markdown tables need a header, so use an empty one
+				out = append(out, prefix+"|"+strings.Repeat("   |", len(cells)))
+				out = append(out, prefix+"|"+strings.Repeat(" --- |", len(cells)))
+				tableHeader = true
+			}
+			out = append(out, prefix+"| "+strings.Join(cells, " | ")+" |")
+		default:
+			out = append(out, prefix+wikiInline(line))
+		}
+	}
+	if fence != "" {
+		out = append(out, "```")
+	}
+	return strings.Join(out, "\n")
+}
+
+func wikiTableCells(row, sep string) []string {
+	cells := strings.Split(strings.Replace(row, "||", "|", -1), "|")
+	for i, cell := range cells {
+		cells[i] = wikiInline(strings.TrimSpace(cell))
+	}
+	return cells
+}
+
+// wikiInline converts the text effects and links in a line, leaving the
+// {{monospace}} text alone.
+func wikiInline(line string) string {
+	parts := wikiMonospace.FindAllStringSubmatchIndex(line, -1)
+	out := ""
+	last := 0
+	for _, part := range parts {
+		out += wikiText(line[last:part[0]]) + fmt.Sprintf("`%s`", line[part[2]:part[3]])
+		last = part[1]
+	}
+	return out + wikiText(line[last:])
+}
+
+func wikiText(text string) string {
+	text = wikiColor.ReplaceAllString(text, "")
+	text = wikiMention.ReplaceAllString(text, "@$1")
+	text = wikiImage.ReplaceAllString(text, "![]($1)")
+	text = wikiLink.ReplaceAllString(text, "[$1]($2)")
+	text = wikiBareLink.ReplaceAllString(text, "<$1>")
+	text = wikiEffect(text, '*', "**")
+	text = wikiEffect(text, '_', "*")
+	text = wikiEffect(text, '-', "~~")
+	return text
+}
+
+// wikiEffect replaces the text effect markers, like *bold*, when they start
+// and end at word boundaries.
+func wikiEffect(text string, marker byte, replacement string) string {
+	space := func(c byte) bool {
+		return c == ' ' || c == '\t'
+	}
+	before := func(i int) bool {
+		return i == 0 || space(text[i-1]) || strings.IndexByte("([", text[i-1]) >= 0
+	}
+	after := func(i int) bool {
+		return i == len(text) || space(text[i]) || strings.IndexByte(").,:;!?]", text[i]) >= 0
+	}
+
+	out := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		if text[i] == marker && before(i) && i+1 < len(text) && !space(text[i+1]) && text[i+1] != marker {
+			end := -1
+			for j := i + 1; j < len(text) && end < 0; j++ {
+				if text[j] == marker && !space(text[j-1]) && after(j+1) {
+					end = j
+				}
+			}
+			if end > 0 {
+				out.WriteString(replacement + text[i+1:end] + replacement)
+				i = end
+				continue
+			}
+		}
+		out.WriteByte(text[i])
+	}
+	return out.String()
+}
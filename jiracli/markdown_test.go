@@ -0,0 +1,101 @@
+package jiracli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWikiToMarkdown(t *testing.T) {
+	for name, test := range map[string]struct {
+		wiki     string
+		expected string
+	}{
+		"plain text": {
+			"Just some text\r\nover two lines",
+			"Just some text\nover two lines",
+		},
+		"headings": {
+			"h1. Title\nh3. *Bold* section\nh7. not a heading",
+			"# Title\n### **Bold** section\nh7. not a heading",
+		},
+		"bullet lists": {
+			"* one\n** nested\n- dash\n*not a list*",
+			"- one\n  - nested\n- dash\n**not a list**",
+		},
+		"numbered lists": {
+			"# first\n## nested\n*# mixed",
+			"1. first\n  1. nested\n  1. mixed",
+		},
+		"code block": {
+			"{code:java}\nint x = 1;\n*not bold*\n{code}",
+			"```java\nint x = 1;\n*not bold*\n```",
+		},
+		"code block with options": {
+			"{code:title=Example.go|borderStyle=solid}\nfmt.Println()\n{code}",
+			"```\nfmt.Println()\n```",
+		},
+		"code block with language and options": {
+			"{code:go|title=main.go}\nfmt.Println()\n{code}",
+			"```go\nfmt.Println()\n```",
+		},
+		"noformat block": {
+			"{noformat}\n  h1. kept\n{noformat}",
+			"```\n  h1. kept\n```",
+		},
+		"single line code": {
+			"{code}x := 1{code}",
+			"```\nx := 1\n```",
+		},
+		"unterminated code": {
+			"{code}\nx := 1",
+			"```\nx := 1\n```",
+		},
+		"code ending on a line": {
+			"{noformat}start\nx := 1{noformat}",
+			"```\nstart\nx := 1\n```",
+		},
+		"quotes": {
+			"bq. a short quote\n{quote}\nfirst *line*\n{quote}\nafter",
+			"> a short quote\n> first **line**\nafter",
+		},
+		"rule": {
+			"above\n----\nbelow",
+			"above\n---\nbelow",
+		},
+		"links": {
+			"see [the docs|https://example.com/docs] or [https://example.com]",
+			"see [the docs](https://example.com/docs) or <https://example.com>",
+		},
+		"mentions and images": {
+			"[~accountid:5b10a2844c20165700ede21g] attached !screenshot.png|thumbnail!",
+			"@5b10a2844c20165700ede21g attached ![](screenshot.png)",
+		},
+		"table with header": {
+			"||Name||Value||\n|one|*1*|\n|two|2|",
+			"| Name | Value |\n| --- | --- |\n| one | **1** |\n| two | 2 |",
+		},
+		"table without header": {
+			"|one|1|\n|two|2|\n\n|three|3|",
+			"|   |   |\n| --- | --- |\n| one | 1 |\n| two | 2 |\n\n|   |   |\n| --- | --- |\n| three | 3 |",
+		},
+		"inline effects": {
+			"*bold* _italic_ -deleted- (*grouped*), end.",
+			"**bold** *italic* ~~deleted~~ (**grouped**), end.",
+		},
+		"effects need word boundaries": {
+			"snake_case_name 2*3*4 a - b well-known-thing",
+			"snake_case_name 2*3*4 a - b well-known-thing",
+		},
+		"monospace": {
+			"run {{jira *view*}} with *care*",
+			"run `jira *view*` with **care**",
+		},
+		"colors and panels": {
+			"{panel:title=Note}{color:red}warning{color}{panel}",
+			"warning",
+		},
+	} {
+		assert.Equal(t, test.expected, wikiToMarkdown(test.wiki), name)
+	}
+}
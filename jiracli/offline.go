@@ -0,0 +1,22 @@
+package jiracli
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-jira/jira"
+)
+
+// OfflineStore returns the store for the issues mirrored from the endpoint
+// by `jira sync`, they are kept in ~/.jira.d/offline/<endpoint host>.
+func OfflineStore(globals *GlobalOptions) *jira.OfflineStore {
+	name := globals.Endpoint.Value
+	if u, err := url.Parse(name); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+	}
+	name = strings.Trim(strings.NewReplacer("/", "_", ":", "_").Replace(name), "_")
+	return &jira.OfflineStore{
+		Dir: filepath.Join(Homedir(), ".jira.d", "offline", name),
+	}
+}
@@ -137,6 +137,11 @@ func (o *GlobalOptions) GetPass() string {
 		return o.cachedPassword
 	}
 
+	if completing {
+		// shell completion must not prompt
+		return ""
+	}
+
 	prompt := fmt.Sprintf("Jira Password [%s]: ", o.Login)
 	help := ""
 
@@ -0,0 +1,273 @@
+package jiracli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/coryb/figtree"
+)
+
+// TemplateProblem is an issue found by CheckTemplate
+type TemplateProblem struct {
+	// Kind is one of "parse", "function", "missing-key" or "execute"
+	Kind    string
+	Message string
+}
+
+func (p *TemplateProblem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Kind, p.Message)
+}
+
+// TemplateNames returns the names of the built-in templates along with the
+// templates found in the .jira.d/templates directories.
+func TemplateNames() ([]string, error) {
+	names := map[string]bool{}
+	for name := range AllTemplates {
+		names[name] = true
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range figtree.FindParentPaths(Homedir(), cwd, filepath.Join(".jira.d", "templates")) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			// the _partials are checked with the templates that use them
+			if file.IsDir() || strings.HasPrefix(file.Name(), ".") || strings.HasPrefix(file.Name(), "_") {
+				continue
+			}
+			names[file.Name()] = true
+		}
+	}
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// CheckTemplate will parse the named template and run it with the sample
+// data for it, discarding the output.  Templates without sample data are only
+// parsed.  A missing key is only reported when the template otherwise runs,
+// since many templates test for optional fields.
+func CheckTemplate(name string) (*TemplateProblem, error) {
+	content, err := getTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := TemplateSample(name)
+	if !ok {
+		data = map[string]interface{}{}
+	}
+	if err := runTemplate(content, data, ioutil.Discard, false); err != nil {
+		if _, exec := err.(template.ExecError); exec && !ok {
+			// without the sample data we can only check it parses
+			return nil, nil
+		}
+		return templateProblem(err), nil
+	}
+	if !ok {
+		return nil, nil
+	}
+	if err := runTemplate(content, data, ioutil.Discard, true); err != nil {
+		return templateProblem(err), nil
+	}
+	return nil, nil
+}
+
+func templateProblem(err error) *TemplateProblem {
+	message := err.Error()
+	if _, ok := err.(template.ExecError); ok {
+		if strings.Contains(message, "map has no entry for key") {
+			return &TemplateProblem{Kind: "missing-key", Message: message}
+		}
+		return &TemplateProblem{Kind: "execute", Message: message}
+	}
+	if strings.Contains(message, "function") && strings.Contains(message, "not defined") {
+		return &TemplateProblem{Kind: "function", Message: message}
+	}
+	return &TemplateProblem{Kind: "parse", Message: message}
+}
+
+// TemplateSample returns the sample data for the template, as sent by the
+// command that uses the template.
+func TemplateSample(name string) (interface{}, bool) {
+	sample, ok := templateSamples[name]
+	if !ok {
+		return nil, false
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(sample), &data); err != nil {
+		// the samples are constants, so this is a bug
+		panic(fmt.Sprintf("invalid %s template sample: %s", name, err))
+	}
+	return data, true
+}
+
+// sampleIssue is an issue with the fields used by the built-in templates
+const sampleIssue = `"key": "GOJIRA-1",
+"id": "10001",
+"self": "https://jira.example.com/rest/api/2/issue/10001",
+"fields": {
+	"summary": "Fix the login page",
+	"description": "The *login* page is broken",
+	"created": "2020-01-02T03:04:05.000+0000",
+	"updated": "2020-01-03T03:04:05.000+0000",
+	"status": {"name": "In Progress", "statusCategory": {"key": "indeterminate", "name": "In Progress"}},
+	"project": {"key": "GOJIRA", "name": "Go Jira"},
+	"issuetype": {"name": "Bug"},
+	"priority": {"name": "Major"},
+	"resolution": null,
+	"components": [{"name": "web"}],
+	"labels": ["login"],
+	"fixVersions": [{"name": "1.0"}],
+	"versions": [{"name": "0.9"}],
+	"votes": {"votes": 1},
+	"assignee": {"name": "alice", "displayName": "Alice", "emailAddress": "alice@example.com", "accountId": "u1"},
+	"reporter": {"name": "bob", "displayName": "Bob", "emailAddress": "bob@example.com", "accountId": "u2"},
+	"customfield_10110": [{"name": "carol"}],
+	"subtasks": [{"key": "GOJIRA-2", "fields": {"summary": "Add a test", "status": {"name": "To Do"}}}],
+	"issuelinks": [
+		{"type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}, "inwardIssue": null, "outwardIssue": {"key": "GOJIRA-3", "fields": {"summary": "Release", "status": {"name": "To Do"}}}},
+		{"type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}, "outwardIssue": null, "inwardIssue": {"key": "GOJIRA-4", "fields": {"summary": "Upgrade", "status": {"name": "Done"}}}}
+	],
+	"comment": {"total": 1, "comments": [{"author": {"name": "bob", "displayName": "Bob"}, "body": "Looks good", "created": "2020-01-03T03:04:05.000+0000"}]}
+}`
+
+const sampleOverrides = `"overrides": {
+	"assignee": "alice", "comment": "", "components": "", "defaultResolution": "Fixed",
+	"description": "", "fixVersions": "", "issuetype": "", "labels": "", "login": "bob",
+	"priority": "", "project": "GOJIRA", "reporter": "", "resolution": "", "summary": "",
+	"versions": "", "watcher": "", "watchers": ""
+}`
+
+const sampleFieldsMeta = `"fields": {
+	"assignee": {"name": "Assignee"},
+	"comment": {"name": "Comment"},
+	"components": {"name": "Components", "allowedValues": [{"name": "web"}, {"name": "api"}]},
+	"customfield_10110": {"name": "Watchers"},
+	"customfield_10120": {"name": "Epic Name"},
+	"description": {"name": "Description"},
+	"fixVersions": {"name": "Fix Versions", "allowedValues": [{"name": "1.0"}]},
+	"issuetype": {"name": "Issue Type", "allowedValues": [{"name": "Bug"}, {"name": "Task"}]},
+	"labels": {"name": "Labels"},
+	"priority": {"name": "Priority", "allowedValues": [{"name": "Major"}, {"name": "Minor"}]},
+	"reporter": {"name": "Reporter"},
+	"resolution": {"name": "Resolution", "allowedValues": [{"name": "Fixed"}]},
+	"summary": {"name": "Summary"},
+	"versions": {"name": "Affects Versions", "allowedValues": [{"name": "0.9"}]}
+}`
+
+const sampleUser = `{"accountId": "u1", "name": "alice", "displayName": "Alice", "emailAddress": "alice@example.com", "active": true, "timeZone": "UTC", "groups": {"items": [{"name": "developers"}]}}`
+
+const sampleRemoteLink = `{"id": 10000, "object": {"title": "Pull Request", "url": "https://github.com/go-jira/jira/pull/1"}}`
+
+const sampleReportIssue = `{"key": "GOJIRA-1", "summary": "Fix the login page", "status": "In Progress", "assignee": "Alice", "url": "https://jira.example.com/browse/GOJIRA-1", "blockedBy": ["GOJIRA-4"],
+	"activity": [{"type": "change", "time": "2020-01-03T03:04:05Z", "author": "Alice", "field": "status", "from": "To Do", "to": "In Progress", "message": "changed status from To Do to In Progress"}]}`
+
+const sampleTransition = `{"id": "21", "name": "Done", "to": {"name": "Done"}, ` + sampleFieldsMeta + `}`
+
+const samplePlan = `"directory": "issues", "unchanged": ["GOJIRA-2"],
+	"counts": {"create": 1, "update": 1, "transition": 0, "epic": 0, "link": 1},
+	"actions": [
+		{"action": "create", "spec": "logout", "file": "issues/logout.yml", "message": "create GOJIRA Story Logout", "applied": true, "skipped": false, "issue": "GOJIRA-7",
+			"fields": [{"id": "summary", "name": "Summary", "lines": [{"op": "+", "text": "Logout"}]}]},
+		{"action": "update", "spec": "login", "file": "issues/login.yml", "issue": "GOJIRA-1", "message": "update GOJIRA-1", "applied": true, "skipped": false,
+			"fields": [{"id": "summary", "name": "Summary", "lines": [{"op": "-", "text": "Fix the login page"}, {"op": "+", "text": "Fix the login and logout pages"}]}]},
+		{"action": "link", "spec": "logout", "file": "issues/logout.yml", "issue": "GOJIRA-7", "message": "link GOJIRA-7 Blocks GOJIRA-1 (outward)", "target": "login", "linkType": "Blocks", "applied": true, "skipped": false, "fields": []}
+	]`
+
+const sampleGraphNode = `"status": {"name": "To Do"}, "assignee": "Alice", "url": "https://jira.example.com/browse/GOJIRA-1",
+	"estimate": "4h", "estimateSeconds": 14400, "resolved": false, "external": false, "cycle": false, "critical": true`
+
+const sampleGraph = `"query": "project = 'GOJIRA' AND resolution = unresolved ORDER BY key",
+	"linkType": {"id": "10000", "name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+	"nodes": [
+		{"key": "GOJIRA-1", "summary": "Fix the \"login\" page", ` + sampleGraphNode + `, "blockedBy": []},
+		{"key": "GOJIRA-2", "summary": "Add a logout button", ` + sampleGraphNode + `, "blockedBy": ["GOJIRA-1"]}
+	],
+	"edges": [{"from": "GOJIRA-1", "to": "GOJIRA-2", "cycle": false, "critical": true}],
+	"cycles": [["GOJIRA-3", "GOJIRA-4"]],
+	"criticalPath": [
+		{"key": "GOJIRA-1", "summary": "Fix the login page", ` + sampleGraphNode + `, "blockedBy": []},
+		{"key": "GOJIRA-2", "summary": "Add a logout button", ` + sampleGraphNode + `, "blockedBy": ["GOJIRA-1"]}
+	],
+	"estimate": "8h", "estimateSeconds": 28800, "unestimated": []`
+
+const sampleTree = `"root": "GOJIRA-5",
+	"rows": [
+		{"key": "GOJIRA-5", "summary": "Login", "status": {"name": "In Progress"}, "issuetype": "Epic", "assignee": "Alice", "url": "https://jira.example.com/browse/GOJIRA-5",
+			"prefix": "", "depth": 0, "relation": "", "cycle": false, "repeated": false},
+		{"key": "GOJIRA-1", "summary": "Fix the login page", "status": {"name": "To Do"}, "issuetype": "Story", "assignee": "", "url": "https://jira.example.com/browse/GOJIRA-1",
+			"prefix": "└── ", "depth": 1, "relation": "epic", "cycle": false, "repeated": false},
+		{"key": "GOJIRA-5", "summary": "Login", "status": {"name": "In Progress"}, "issuetype": "Epic", "assignee": "Alice", "url": "https://jira.example.com/browse/GOJIRA-5",
+			"prefix": "    └── ", "depth": 2, "relation": "blocks", "cycle": true, "repeated": false}
+	],
+	"nodes": [
+		{"key": "GOJIRA-5", "summary": "Login", "status": {"name": "In Progress"}, "issuetype": "Epic", "assignee": "Alice", "url": "https://jira.example.com/browse/GOJIRA-5"},
+		{"key": "GOJIRA-1", "summary": "Fix the \"login\" page", "status": {"name": "To Do"}, "issuetype": "Story", "assignee": "", "url": "https://jira.example.com/browse/GOJIRA-1"}
+	],
+	"edges": [
+		{"from": "GOJIRA-5", "to": "GOJIRA-1", "relation": "epic", "cycle": false},
+		{"from": "GOJIRA-1", "to": "GOJIRA-5", "relation": "blocks", "cycle": true}
+	]`
+
+var templateSamples = map[string]string{
+	"apply":      `{` + samplePlan + `, "applied": {"create": 1, "update": 1, "transition": 0, "epic": 0, "link": 1}}`,
+	"comment":    `{` + sampleOverrides + `}`,
+	"components": `[{"id": "10000", "name": "web"}]`,
+	"create":     `{"meta": {"name": "Bug", ` + sampleFieldsMeta + `}, ` + sampleOverrides + `}`,
+	"debug":      `{` + sampleIssue + `}`,
+	"diff": `{"issue": "GOJIRA-1", "from": "GOJIRA-1 (live)", "to": "edit.yml", "fields": [{"id": "summary", "name": "Summary",
+		"lines": [{"op": "-", "text": "Fix the login page"}, {"op": "+", "text": "Fix the login and logout pages"}]}]}`,
+	"edit":          `{` + sampleIssue + `, "meta": {` + sampleFieldsMeta + `}, ` + sampleOverrides + `}`,
+	"epic-create":   `{"meta": {"name": "Epic", ` + sampleFieldsMeta + `}, ` + sampleOverrides + `}`,
+	"epic-list":     `{"issues": [{` + sampleIssue + `}], "total": 1}`,
+	"graph":         `{` + sampleGraph + `}`,
+	"graph-dot":     `{` + sampleGraph + `}`,
+	"graph-mermaid": `{` + sampleGraph + `}`,
+	"json":          `{` + sampleIssue + `}`,
+	"list":          `{"issues": [{` + sampleIssue + `}], "total": 1}`,
+	"myself":        sampleUser,
+	"plan":          `{` + samplePlan + `}`,
+	"pr": `{` + sampleIssue + `, "endpoint": "https://jira.example.com",
+		"epic": {"key": "GOJIRA-5", "fields": {"summary": "Login", "status": {"name": "In Progress"}}},
+		"epicIssues": [{"key": "GOJIRA-6", "fields": {"summary": "Logout", "status": {"name": "To Do"}}}],
+		"acceptanceCriteria": "* it works", "remotelinks": [` + sampleRemoteLink + `], "pullRequest": "https://github.com/go-jira/jira/pull/2"
+	}`,
+	"remotelinks":    `[` + sampleRemoteLink + `]`,
+	"report-standup": `{"since": "2020-01-02T03:04:05Z", "until": "2020-01-03T03:04:05Z", "query": "updated >= -1440m", "people": [{"name": "Alice", "issues": [` + sampleReportIssue + `]}]}`,
+	"report-weekly": `{"since": "2019-12-27T03:04:05Z", "until": "2020-01-03T03:04:05Z", "query": "resolved >= -10080m",
+		"epics": [{"key": "GOJIRA-5", "summary": "Login", "url": "https://jira.example.com/browse/GOJIRA-5",
+			"resolved": [` + sampleReportIssue + `], "inProgress": [` + sampleReportIssue + `], "blocked": [` + sampleReportIssue + `]}]
+	}`,
+	"subtask":      `{"parent": {` + sampleIssue + `}, "meta": {"name": "Sub-task", ` + sampleFieldsMeta + `}, ` + sampleOverrides + `}`,
+	"table":        `{"issues": [{` + sampleIssue + `}], "total": 1}`,
+	"transition":   `{` + sampleIssue + `, "meta": ` + sampleTransition + `, "transition": ` + sampleTransition + `, ` + sampleOverrides + `}`,
+	"transitions":  `{"transitions": [` + sampleTransition + `]}`,
+	"tree":         `{` + sampleTree + `}`,
+	"tree-dot":     `{` + sampleTree + `}`,
+	"tree-mermaid": `{` + sampleTree + `}`,
+	"user":         sampleUser,
+	"users":        `[` + sampleUser + `]`,
+	"view": `{` + sampleIssue + `,
+		"watchers": [{"name": "carol", "displayName": "Carol"}],
+		"remotelinks": [{"object": {"title": "Pull Request", "url": "https://github.com/go-jira/jira/pull/1"}}]
+	}`,
+	"watch-query": `{"type": "status", "issue": "GOJIRA-1", "summary": "Fix the login page", "from": "To Do", "to": "In Progress",
+		"message": "status changed from To Do to In Progress", "url": "https://jira.example.com/browse/GOJIRA-1", "time": "2020-01-03T03:04:05Z"}`,
+	"watchers": `{"watchers": [{"name": "carol", "displayName": "Carol", "emailAddress": "carol@example.com"}]}`,
+	"worklog":  `{"issue": "GOJIRA-1", "comment": "", "timeSpent": "1h", "started": "2020-01-03T03:04:05.000+0000"}`,
+	"worklogs": `{"worklogs": [{"author": {"displayName": "Alice"}, "comment": "pairing", "created": "2020-01-03T03:04:05.000+0000", "started": "2020-01-03T03:04:05.000+0000", "timeSpent": "1h"}]}`,
+}
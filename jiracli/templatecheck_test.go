@@ -0,0 +1,30 @@
+package jiracli
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinTemplates(t *testing.T) {
+	// make sure we do not pick up any customized templates
+	dir, err := ioutil.TempDir("", "jira-templates")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer os.Chdir(cwd)
+	assert.NoError(t, os.Chdir(dir))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	// the samples should have all the keys the built-in templates use, so
+	// a missing key here means the sample or the template needs fixing
+	for name := range AllTemplates {
+		problem, err := CheckTemplate(name)
+		assert.NoError(t, err, name)
+		assert.Nil(t, problem, name)
+	}
+}
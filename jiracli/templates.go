@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -19,7 +20,6 @@ import (
 	"github.com/Masterminds/sprig"
 	"github.com/coryb/figtree"
 	shellquote "github.com/kballard/go-shellquote"
-	"github.com/mgutz/ansi"
 	wordwrap "github.com/mitchellh/go-wordwrap"
 	"github.com/olekukonko/tablewriter"
 	"golang.org/x/crypto/ssh/terminal"
@@ -57,6 +57,76 @@ func getTemplate(name string) (string, error) {
 	return "", fmt.Errorf("No Template found for %q", name)
 }
 
+// findPartials returns the templates in the .jira.d/templates/_partials
+// directories, by file name without the extension.  The partials in the
+// directories closest to the current directory win.
+func findPartials() (map[string]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	partials := map[string]string{}
+	for _, dir := range figtree.FindParentPaths(Homedir(), cwd, filepath.Join(".jira.d", "templates", "_partials")) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+				continue
+			}
+			b, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			partials[strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))] = string(b)
+		}
+	}
+	return partials, nil
+}
+
+// templateExtends matches the comment at the start of a template that only
+// overrides some of the blocks in a built-in template, ie:
+//
+//	{{/* extends "view" */}}
+//	{{ define "view.description" }}...{{ end }}
+var templateExtends = regexp.MustCompile(`^\s*{{-?\s*/\*\s*extends\s+"([^"]+)"\s*\*/\s*-?}}`)
+
+// parseTemplate will parse the partials and the template content into tmpl.
+// When the content extends a built-in template the built-in is parsed first
+// so the blocks defined in the content replace the ones in the built-in.
+func parseTemplate(tmpl *template.Template, content string) (*template.Template, error) {
+	partials, err := findPartials()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for name := range partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := tmpl.New(name).Parse(partials[name]); err != nil {
+			return nil, fmt.Errorf("partial %q: %s", name, err)
+		}
+	}
+
+	if match := templateExtends.FindStringSubmatch(content); match != nil {
+		base, ok := AllTemplates[match[1]]
+		if !ok {
+			return nil, fmt.Errorf("Unable to extend %q, it is not a built-in template", match[1])
+		}
+		if _, err := tmpl.Parse(base); err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New("extends").Parse(content); err != nil {
+			return nil, err
+		}
+		return tmpl, nil
+	}
+	return tmpl.Parse(content)
+}
+
 func tmpTemplate(templateName string, data interface{}) (string, error) {
 	tmpFile, err := tmpYml(templateName)
 	if err != nil {
@@ -128,6 +198,13 @@ func TemplateProcessor() *template.Template {
 				return "", fmt.Errorf("Unknown type: %s", value)
 			}
 		},
+		"markdown": func(content interface{}) string {
+			// descriptions and comments can be null, so be forgiving
+			if text, ok := content.(string); ok {
+				return wikiToMarkdown(text)
+			}
+			return ""
+		},
 		"indent": func(spaces int, content string) string {
 			indent := make([]rune, spaces+1)
 			indent[0] = '\n'
@@ -151,7 +228,26 @@ func TemplateProcessor() *template.Template {
 			return content
 		},
 		"color": func(color string) string {
-			return ansi.ColorCode(color)
+			return colorCode(color)
+		},
+		"statusColor": func(status interface{}) string {
+			return themeColor("status", status)
+		},
+		"priorityColor": func(priority interface{}) string {
+			return themeColor("priority", priority)
+		},
+		"typeColor": func(issuetype interface{}) string {
+			return themeColor("issuetype", issuetype)
+		},
+		"link": func(url string, text string) string {
+			return hyperlink(url, text)
+		},
+		"issueURL": func(key string) string {
+			return issueURL(key)
+		},
+		"field": templateFieldID,
+		"styleMarkdown": func(width int, content string) string {
+			return styleMarkdown(width, content)
 		},
 		"remLineBreak": func(content string) string {
 			return strings.Replace(strings.Replace(content, string('\r'), string(' '), -1), string('\n'), string(' '), -1)
@@ -251,9 +347,14 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 	if out == nil {
 		out = os.Stdout
 	}
+	return runTemplate(templateContent, data, out, false)
+}
 
+// runTemplate will execute the template content with the data, when strict
+// is set it is an error for the template to use a key missing from the data.
+func runTemplate(templateContent string, data interface{}, out io.Writer, strict bool) error {
 	var rawData interface{}
-	err = ConvertType(data, &rawData)
+	err := ConvertType(data, &rawData)
 	if err != nil {
 		return err
 	}
@@ -262,7 +363,8 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 	table.SetAutoFormatHeaders(false)
 	headers := []string{}
 	cells := [][]string{}
-	tmpl, err := TemplateProcessor().Funcs(map[string]interface{}{
+	var tmpl *template.Template
+	tmpl, err = parseTemplate(TemplateProcessor().Funcs(map[string]interface{}{
 		"defaultColWidth": func(cw int) string {
 			table.SetColWidth(cw)
 			return ""
@@ -282,10 +384,22 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 			cells[len(cells)-1] = append(cells[len(cells)-1], fmt.Sprintf("%v", value))
 			return "", nil
 		},
-	}).Parse(templateContent)
+		"include": func(name string, data interface{}) (string, error) {
+			// like the template action, but the output can be piped
+			// to other functions, ie: {{ include "user" .assignee | indent 2 }}
+			buf := bytes.NewBufferString("")
+			if err := tmpl.ExecuteTemplate(buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	}), templateContent)
 	if err != nil {
 		return err
 	}
+	if strict {
+		tmpl.Option("missingkey=error")
+	}
 
 	if err := tmpl.Execute(out, rawData); err != nil {
 		return err
@@ -300,40 +414,70 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 }
 
 var AllTemplates = map[string]string{
+	"apply":          defaultApplyTemplate,
 	"attach-list":    defaultAttachListTemplate,
+	"cache-show":     defaultCacheShowTemplate,
 	"comment":        defaultCommentTemplate,
 	"component-add":  defaultComponentAddTemplate,
 	"components":     defaultComponentsTemplate,
 	"create":         defaultCreateTemplate,
 	"createmeta":     defaultDebugTemplate,
 	"debug":          defaultDebugTemplate,
+	"diff":           defaultDiffTemplate,
 	"edit":           defaultEditTemplate,
 	"editmeta":       defaultDebugTemplate,
 	"epic-create":    defaultEpicCreateTemplate,
 	"epic-list":      defaultTableTemplate,
 	"fields":         defaultDebugTemplate,
+	"filter":         defaultFilterTemplate,
+	"filter-create":  defaultFilterEditTemplate,
+	"filter-update":  defaultFilterEditTemplate,
+	"filters":        defaultFiltersTemplate,
+	"graph":          defaultGraphTemplate,
+	"graph-dot":      defaultGraphDotTemplate,
+	"graph-mermaid":  defaultGraphMermaidTemplate,
+	"group-members":  defaultGroupMembersTemplate,
 	"issuelinktypes": defaultDebugTemplate,
 	"issuetypes":     defaultIssuetypesTemplate,
 	"json":           defaultDebugTemplate,
 	"list":           defaultListTemplate,
+	"myself":         defaultUserTemplate,
+	"plan":           defaultPlanTemplate,
+	"pr":             defaultPrTemplate,
+	"properties":     defaultPropertiesTemplate,
+	"property":       defaultPropertyTemplate,
+	"remotelinks":    defaultRemoteLinksTemplate,
+	"report-standup": defaultReportStandupTemplate,
+	"report-weekly":  defaultReportWeeklyTemplate,
 	"request":        defaultDebugTemplate,
 	"subtask":        defaultSubtaskTemplate,
 	"table":          defaultTableTemplate,
 	"transition":     defaultTransitionTemplate,
 	"transitions":    defaultTransitionsTemplate,
 	"transmeta":      defaultDebugTemplate,
+	"tree":           defaultTreeTemplate,
+	"tree-dot":       defaultTreeDotTemplate,
+	"tree-mermaid":   defaultTreeMermaidTemplate,
+	"user":           defaultUserTemplate,
+	"users":          defaultUsersTemplate,
 	"view":           defaultViewTemplate,
+	"view-markdown":  defaultViewMarkdownTemplate,
+	"watch-query":    defaultWatchQueryTemplate,
+	"watchers":       defaultWatchersTemplate,
 	"worklog":        defaultWorklogTemplate,
 	"worklogs":       defaultWorklogsTemplate,
 }
 
 const defaultDebugTemplate = "{{ . | toJson}}\n"
 
-const defaultListTemplate = "{{ range .issues }}{{ .key | append \":\" | printf \"%-12s\"}} {{ .fields.summary }}\n{{ end }}"
+const defaultListTemplate = "{{ range .issues }}{{ block \"list.issue\" . }}{{ .key | append \":\" | printf \"%-12s\" | link (issueURL .key) }} {{ .fields.summary }}\n{{ end }}{{ end }}"
 
 const defaultTableTemplate = `{{/* table template */ -}}
+{{- block "table.headers" . -}}
 {{- headers "Issue" "Summary" "Type" "Priority" "Status" "Age" "Reporter" "Assignee" -}}
+{{- end -}}
 {{- range .issues -}} 
+  {{- block "table.issue" . -}}
   {{- row -}}
   {{- cell .key -}}
   {{- cell .fields.summary -}}
@@ -355,6 +499,7 @@ const defaultTableTemplate = `{{/* table template */ -}}
   {{- else -}}
     {{- cell "<unassigned>" -}}
   {{- end -}}
+  {{- end -}}
 {{- end -}}
 `
 
@@ -370,13 +515,71 @@ const defaultAttachListTemplate = `{{/* attach list template */ -}}
 {{- end -}}
 `
 
+const defaultPrTemplate = `{{/* pr template */ -}}
+## [{{ .key }}]({{ .endpoint }}/browse/{{ .key }}): {{ .fields.summary }}
+{{ if .epic }}
+Epic: [{{ .epic.key }}]({{ .endpoint }}/browse/{{ .epic.key }}) {{ .epic.fields.summary }}
+{{ end -}}
+{{ if .fields.description }}
+### Description
+
+{{ markdown .fields.description }}
+{{ end -}}
+{{ if .acceptanceCriteria }}
+### Acceptance Criteria
+
+{{ markdown .acceptanceCriteria }}
+{{ end -}}
+{{ if .fields.subtasks }}
+### Sub-tasks
+
+{{ range .fields.subtasks }}- [{{ .key }}]({{ $.endpoint }}/browse/{{ .key }}) {{ .fields.summary }}{{ if .fields.status }} ({{ .fields.status.name }}){{ end }}
+{{ end -}}
+{{ end -}}
+{{ if .epicIssues }}
+### Issues in Epic
+
+{{ range .epicIssues }}- [{{ .key }}]({{ $.endpoint }}/browse/{{ .key }}) {{ .fields.summary }}{{ if .fields.status }} ({{ .fields.status.name }}){{ end }}
+{{ end -}}
+{{ end -}}
+{{ if .fields.issuelinks }}
+### Linked Issues
+
+{{ range .fields.issuelinks }}{{ if .outwardIssue }}- {{ .type.outward }} [{{ .outwardIssue.key }}]({{ $.endpoint }}/browse/{{ .outwardIssue.key }}) {{ .outwardIssue.fields.summary }}
+{{ else if .inwardIssue }}- {{ .type.inward }} [{{ .inwardIssue.key }}]({{ $.endpoint }}/browse/{{ .inwardIssue.key }}) {{ .inwardIssue.fields.summary }}
+{{ end }}{{ end -}}
+{{ end -}}
+{{ if .remotelinks }}
+### Links
+
+{{ range .remotelinks }}{{ if ne .object.url $.pullRequest }}- [{{ .object.title }}]({{ .object.url }})
+{{ end }}{{ end -}}
+{{ end -}}
+`
+
+const defaultPropertiesTemplate = `{{ range .keys }}{{ .key }}
+{{end}}`
+
+const defaultPropertyTemplate = "{{ .value | toJson }}\n"
+
+const defaultRemoteLinksTemplate = `{{/* remote links template */ -}}
+{{- headers "id" "title" "url" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell .id -}}
+  {{- cell .object.title -}}
+  {{- cell .object.url -}}
+{{- end -}}
+`
+
 const defaultViewTemplate = `{{/* view template */ -}}
-issue: {{ .key }}
+{{ block "view.fields" . -}}
+issue: {{ .key | link (issueURL .key) }}
 {{if .fields.created -}}
 created: {{ .fields.created | age }} ago
 {{end -}}
 {{if .fields.status -}}
-status: {{ .fields.status.name }}
+status: {{ statusColor .fields.status }}{{ .fields.status.name }}{{ color "reset" }}
 {{end -}}
 summary: {{ .fields.summary }}
 project: {{ .fields.project.key }}
@@ -384,21 +587,21 @@ project: {{ .fields.project.key }}
 components: {{ range .fields.components }}{{ .name }} {{end}}
 {{end -}}
 {{if .fields.issuetype -}}
-issuetype: {{ .fields.issuetype.name }}
+issuetype: {{ typeColor .fields.issuetype }}{{ .fields.issuetype.name }}{{ color "reset" }}
 {{end -}}
 {{if .fields.assignee -}}
 assignee: {{ .fields.assignee.displayName }}
 {{end -}}
 reporter: {{ if .fields.reporter }}{{ .fields.reporter.displayName }}{{end}}
-{{if .fields.customfield_10110 -}}
-watchers: {{ range .fields.customfield_10110 }}{{ .displayName }} {{end}}
+{{if .watchers -}}
+watchers: {{ range .watchers }}{{ .displayName }} {{end}}
 {{end -}}
 {{if .fields.issuelinks -}}
 blockers: {{ range .fields.issuelinks }}{{if .outwardIssue}}{{ .outwardIssue.key }}[{{.outwardIssue.fields.status.name}}]{{end}}{{end}}
 depends: {{ range .fields.issuelinks }}{{if .inwardIssue}}{{ .inwardIssue.key }}[{{.inwardIssue.fields.status.name}}]{{end}}{{end}}
 {{end -}}
 {{if .fields.priority -}}
-priority: {{ .fields.priority.name }}
+priority: {{ priorityColor .fields.priority }}{{ .fields.priority.name }}{{ color "reset" }}
 {{end -}}
 {{if .fields.votes -}}
 votes: {{ .fields.votes.votes}}
@@ -406,15 +609,139 @@ votes: {{ .fields.votes.votes}}
 {{if .fields.labels -}}
 labels: {{ join ", " .fields.labels }}
 {{end -}}
+{{if .remotelinks -}}
+links:
+{{ range .remotelinks }}  - {{ .object.title }}: {{ .object.url }}
+{{end}}
+{{- end -}}
+{{ end -}}
+{{ block "view.description" . -}}
 description: |
   {{ or .fields.description "" | indent 2 }}
+{{ end -}}
+{{ block "view.comments" . -}}
 {{if .fields.comment.comments}}
 comments:
 {{ range .fields.comment.comments }}  - | # {{.author.displayName}}, {{.created | age}} ago
     {{ or .body "" | indent 4}}
 {{end}}
 {{end -}}
+{{ end -}}
+`
+const defaultViewMarkdownTemplate = `{{/* extends "view" */}}
+{{ define "view.description" -}}
+description:
+  {{ markdown .fields.description | styleMarkdown (sub termWidth 2) | indent 2 }}
+{{ end }}
+{{ define "view.comments" -}}
+{{ if .fields.comment.comments }}
+comments:
+{{ range .fields.comment.comments }}
+  {{ color "+b" }}{{ .author.displayName }}{{ color "reset" }}, {{ .created | age }} ago:
+    {{ markdown .body | styleMarkdown (sub termWidth 4) | indent 4 }}
+{{ end }}{{ end -}}
+{{ end }}`
+const defaultDiffTemplate = `{{/* diff template */ -}}
+{{ color "red" }}--- {{ .from }}{{ color "reset" }}
+{{ color "green" }}+++ {{ .to }}{{ color "reset" }}
+{{- range .fields }}
+{{ color "cyan" }}@@ {{ .name }} @@{{ color "reset" }}
+{{- range .lines }}
+{{ if eq .op "-" }}{{ color "red" }}{{ else if eq .op "+" }}{{ color "green" }}{{ end }}{{ .op }}{{ .text }}{{ if ne .op " " }}{{ color "reset" }}{{ end }}
+{{- end }}
+{{- else }}
+no differences
+{{- end }}
+`
+
+const defaultPlanTemplate = `{{/* plan template */ -}}
+{{ range .actions -}}
+{{ if eq .action "create" }}{{ color "green" }}+{{ else if eq .action "update" }}{{ color "yellow" }}~{{ else }}{{ color "cyan" }}>{{ end }} {{ .message }}{{ color "reset" }} [{{ .spec }}]
+{{ range .fields }}    {{ .name }}:
+{{ range .lines }}{{ if ne .op " " }}      {{ if eq .op "-" }}{{ color "red" }}{{ else }}{{ color "green" }}{{ end }}{{ .op }} {{ .text }}{{ color "reset" }}
+{{ end }}{{ end }}{{ end -}}
+{{ end -}}
+{{ if .actions -}}
+Plan: {{ .counts.create }} to create, {{ .counts.update }} to update, {{ .counts.transition }} to transition, {{ .counts.epic }} to add to epics, {{ .counts.link }} to link, {{ len .unchanged }} unchanged.
+{{ else -}}
+No changes, {{ len .unchanged }} issues match the specs in {{ .directory }}.
+{{ end -}}
+`
+
+const defaultApplyTemplate = `{{/* apply template */ -}}
+{{ range .actions -}}
+{{ if .skipped }}SKIP {{ .issue }} {{ .message }}
+{{ else if .applied }}OK {{ .issue }} {{ .message }}
+{{ end }}{{ end -}}
+Applied: {{ .applied.create }} created, {{ .applied.update }} updated, {{ .applied.transition }} transitioned, {{ .applied.epic }} added to epics, {{ .applied.link }} linked, {{ len .unchanged }} unchanged.
+`
+
+const defaultTreeTemplate = `{{/* tree template */ -}}
+{{ range .rows -}}
+{{ .prefix }}{{ if .relation }}{{ color "cyan" }}{{ .relation }}{{ color "reset" }} {{ end }}{{ link .url .key }} {{ statusColor .status }}[{{ .status.name }}]{{ color "reset" }} {{ .summary }}{{ if .assignee }} ({{ .assignee }}){{ end }}
+{{- if .cycle }} {{ color "red" }}(cycle){{ color "reset" }}{{ else if .repeated }} (see above){{ end }}
+{{ end -}}
+`
+
+const defaultTreeDotTemplate = `{{/* tree dot template */ -}}
+digraph {{ .root | quote }} {
+  node [shape=box];
+{{ range .nodes }}  {{ .key | quote }} [label={{ printf "%s [%s]\n%s" .key .status.name .summary | quote }}, URL={{ .url | quote }}];
+{{ end -}}
+{{ range .edges }}  {{ .from | quote }} -> {{ .to | quote }} [label={{ .relation | quote }}{{ if .cycle }}, color=red{{ end }}];
+{{ end -}}
+}
+`
+
+const defaultTreeMermaidTemplate = `{{/* tree mermaid template */ -}}
+graph TD
+{{ range .nodes }}  {{ .key | replace "-" "_" }}["{{ .key }} [{{ .status.name }}]<br/>{{ .summary | replace "\"" "#quot;" }}"]
+{{ end -}}
+{{ range .edges }}  {{ .from | replace "-" "_" }} {{ if .cycle }}-.->{{ else }}-->{{ end }}|{{ .relation }}| {{ .to | replace "-" "_" }}
+{{ end -}}
+`
+
+const defaultGraphTemplate = `{{/* graph template */ -}}
+{{ range .cycles -}}
+{{ color "red" }}cycle between {{ join ", " . }}{{ color "reset" }}
+{{ end -}}
+{{ range .nodes }}{{ if and .blockedBy (not .resolved) -}}
+{{ link .url .key }} {{ statusColor .status }}[{{ .status.name }}]{{ color "reset" }} {{ .summary }}{{ if .assignee }} ({{ .assignee }}){{ end }}
+  {{ $.linkType.inward }} {{ join ", " .blockedBy }}
+{{ end }}{{ end -}}
+{{ if .criticalPath -}}
+Critical path: {{ len .criticalPath }} issues, {{ .estimate }}{{ if .unestimated }} ({{ len .unestimated }} without an estimate){{ end }}
+{{ range .criticalPath }}  {{ link .url .key }} {{ statusColor .status }}[{{ .status.name }}]{{ color "reset" }} {{ .summary }}{{ if .estimate }} ({{ .estimate }}){{ end }}
+{{ end -}}
+{{ else -}}
+No unresolved issue {{ .linkType.outward }} another unresolved issue.
+{{ end -}}
+`
+
+const defaultGraphDotTemplate = `{{/* graph dot template */ -}}
+digraph dependencies {
+  rankdir=LR;
+  node [shape=box];
+{{ range .nodes }}  {{ .key | quote }} [label={{ printf "%s [%s] %s\n%s" .key .status.name .estimate .summary | quote }}, URL={{ .url | quote }}
+  {{- if .critical }}, color=red, penwidth=2{{ end }}{{ if .resolved }}, fontcolor=gray{{ end }}{{ if .external }}, style=dashed{{ end }}];
+{{ end -}}
+{{ range .edges }}  {{ .from | quote }} -> {{ .to | quote }}
+  {{- if .critical }} [color=red, penwidth=2]{{ else if .cycle }} [color=orange, style=dashed]{{ end }};
+{{ end -}}
+}
+`
+
+const defaultGraphMermaidTemplate = `{{/* graph mermaid template */ -}}
+graph LR
+{{ range .nodes }}  {{ .key | replace "-" "_" }}["{{ .key }} [{{ .status.name }}]{{ if .estimate }} {{ .estimate }}{{ end }}<br/>{{ .summary | replace "\"" "#quot;" }}"]
+{{ end -}}
+{{ range .edges }}  {{ .from | replace "-" "_" }} {{ if .cycle }}-.->{{ else if .critical }}==>{{ else }}-->{{ end }} {{ .to | replace "-" "_" }}
+{{ end -}}
+{{ if .criticalPath }}  classDef critical stroke:#f00,stroke-width:2px
+  class {{ range $i, $node := .criticalPath }}{{ if $i }},{{ end }}{{ $node.key | replace "-" "_" }}{{ end }} critical
+{{ end -}}
 `
+
 const defaultEditTemplate = `{{/* edit template */ -}}
 # issue: {{ .key }} - created: {{ .fields.created | age}} ago
 update:
@@ -441,11 +768,11 @@ fields:
 {{- if .meta.fields.reporter}}
   reporter:
     emailAddress: {{ if .overrides.reporter }}{{ .overrides.reporter }}{{else if .fields.reporter}}{{ .fields.reporter.emailAddress }}{{end}}{{end}}
-{{- if .meta.fields.customfield_10110}}
+{{- with .meta.fields }}{{ $watchers := field "Watchers" . }}{{ if index . $watchers }}
   # watchers
-  customfield_10110: {{ range .fields.customfield_10110 }}
-    - name: {{ .name }}{{end}}{{if .overrides.watcher}}
-    - name: {{ .overrides.watcher}}{{end}}{{end}}
+  {{ $watchers }}: {{ range index $.fields $watchers }}
+    - name: {{ .name }}{{end}}{{if $.overrides.watcher}}
+    - name: {{ $.overrides.watcher}}{{end}}{{end}}{{end}}
 {{- if .meta.fields.priority }}
   priority: # Values: {{ range .meta.fields.priority.allowedValues }}{{.name}}, {{end}}
     name: {{ or .overrides.priority .fields.priority.name "" }}{{end}}
@@ -470,6 +797,140 @@ description: {{or .description ""}}
 leadUserName: {{or .leadUserName ""}}
 `
 
+const defaultCacheShowTemplate = `{{/* cache-show template */ -}}
+{{- headers "uri" "login" "fetched" "expires" "size" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell .uri -}}
+  {{- cell .login -}}
+  {{- cell (.fetched | toDate "2006-01-02T15:04:05Z07:00" | date "2006-01-02 15:04:05") -}}
+  {{- cell (.expires | toDate "2006-01-02T15:04:05Z07:00" | date "2006-01-02 15:04:05") -}}
+  {{- cell .size -}}
+{{- end -}}
+`
+
+const defaultFiltersTemplate = `{{/* filters template */ -}}
+{{- headers "id" "name" "owner" "jql" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell .id -}}
+  {{- cell .name -}}
+  {{- cell (or .owner.displayName .owner.name "") -}}
+  {{- cell .jql -}}
+{{- end -}}
+`
+
+const defaultFilterTemplate = `{{/* filter template */ -}}
+id: {{ .id }}
+name: {{ .name }}
+{{if .owner -}}
+owner: {{ or .owner.displayName .owner.name }}
+{{end -}}
+favourite: {{ or .favourite false }}
+{{if .description -}}
+description: {{ .description }}
+{{end -}}
+jql: {{ .jql }}
+{{if .viewUrl -}}
+url: {{ .viewUrl }}
+{{end -}}
+`
+
+const defaultFilterEditTemplate = `{{/* filter create/update template */ -}}
+name: {{ or .name "" }}
+description: |~
+  {{ or .description "" | indent 2 }}
+jql: >-
+  {{ or .jql "" }}
+favourite: {{ or .favourite false }}
+`
+
+const defaultUsersTemplate = `{{/* users template */ -}}
+{{- headers "account" "name" "email" "active" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell (or .accountId .name) -}}
+  {{- cell .displayName -}}
+  {{- cell (or .emailAddress "") -}}
+  {{- cell (or .active false) -}}
+{{- end -}}
+`
+
+const defaultGroupMembersTemplate = `{{/* group members template */ -}}
+{{- headers "account" "name" "email" "active" -}}
+{{- range .values -}}
+  {{- row -}}
+  {{- cell (or .accountId .name) -}}
+  {{- cell .displayName -}}
+  {{- cell (or .emailAddress "") -}}
+  {{- cell (or .active false) -}}
+{{- end -}}
+`
+
+const defaultUserTemplate = `{{/* user template */ -}}
+{{if .accountId -}}
+accountId: {{ .accountId }}
+{{end -}}
+{{if .name -}}
+name: {{ .name }}
+{{end -}}
+displayName: {{ .displayName }}
+{{if .emailAddress -}}
+email: {{ .emailAddress }}
+{{end -}}
+active: {{ or .active false }}
+{{if .timeZone -}}
+timeZone: {{ .timeZone }}
+{{end -}}
+{{if .groups -}}
+groups: {{ range .groups.items }}{{ .name }} {{end}}
+{{end -}}
+`
+
+const defaultWatchersTemplate = `{{/* watchers template */ -}}
+{{ range .watchers }}{{ .displayName }}{{if .emailAddress}} <{{ .emailAddress }}>{{end}}
+{{end}}`
+
+const defaultReportStandupTemplate = `{{/* report-standup template */ -}}
+## Stand-up since {{ .since | toDate "2006-01-02T15:04:05Z07:00" | date "Mon Jan 2 15:04" }}
+{{ range .people }}
+### {{ .name }}
+{{ range .issues }}
+- [{{ .key }}]({{ .url }}) {{ .summary }} ({{ .status }})
+{{- range .activity }}
+  - {{ .message }}
+{{- end }}
+{{- end }}
+{{ else }}
+No activity.
+{{ end -}}
+`
+
+const defaultReportWeeklyTemplate = `{{/* report-weekly template */ -}}
+## Week of {{ .since | toDate "2006-01-02T15:04:05Z07:00" | date "Mon Jan 2" }}
+{{ range .epics }}
+### {{ if .key }}[{{ .key }}]({{ .url }}) {{ .summary }}{{ else }}No Epic{{ end }}
+{{ if .resolved }}
+Resolved:
+{{ range .resolved }}- [{{ .key }}]({{ .url }}) {{ .summary }}
+{{ end }}{{ end }}
+{{- if .inProgress }}
+In Progress:
+{{ range .inProgress }}- [{{ .key }}]({{ .url }}) {{ .summary }}{{ if .assignee }} ({{ .assignee }}){{ end }}
+{{ end }}{{ end }}
+{{- if .blocked }}
+Blocked:
+{{ range .blocked }}- [{{ .key }}]({{ .url }}) {{ .summary }}{{ if .blockedBy }} (blocked by {{ join ", " .blockedBy }}){{ end }}
+{{ end }}{{ end }}
+{{- else }}
+Nothing to report.
+{{ end -}}
+`
+
+const defaultWatchQueryTemplate = `{{/* watch-query template */ -}}
+{{ .time | toDate "2006-01-02T15:04:05Z07:00" | date "15:04:05" }} {{color "+bh"}}{{ .issue | append ":" | printf "%-12s" }}{{color "reset"}} {{ .message }} ({{ .summary }})
+`
+
 const defaultIssuetypesTemplate = `{{/* issuetypes template */ -}}
 {{ range .issuetypes }}{{color "+bh"}}{{.name | append ":" | printf "%-13s" }}{{color "reset"}} {{.description}}
 {{end}}`
@@ -491,18 +952,19 @@ fields:
   assignee:
     emailAddress: {{ or .overrides.assignee "" }}{{end}}{{if .meta.fields.reporter}}
   reporter:
-    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{if .meta.fields.customfield_10110}}
+    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{ with .meta.fields }}{{ $watchers := field "Watchers" . }}{{ if index . $watchers }}
   # watchers
-  customfield_10110: {{ range split "," (or .overrides.watchers "")}}
+  {{ $watchers }}: {{ range split "," (or $.overrides.watchers "")}}
     - name: {{.}}{{end}}
-    - name:{{end}}`
+    - name:{{end}}{{end}}`
 
 const defaultEpicCreateTemplate = `{{/* epic create template */ -}}
 fields:
   project:
     key: {{ or .overrides.project "" }}
+  {{- with .meta.fields }}{{ $epicName := field "Epic Name" . }}{{ if index . $epicName }}
   # Epic Name
-  customfield_10120: {{ or (index .overrides "epic-name") "" }}
+  {{ $epicName }}: {{ or (index $.overrides "epic-name") "" }}{{ end }}{{ end }}
   summary: >-
     {{ or .overrides.summary "" }}{{if .meta.fields.priority.allowedValues}}
   priority: # Values: {{ range .meta.fields.priority.allowedValues }}{{.name}}, {{end}}
@@ -514,13 +976,13 @@ fields:
   assignee:
     emailAddress: {{ or .overrides.assignee "" }}{{end}}{{if .meta.fields.reporter}}
   reporter:
-    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{if .meta.fields.customfield_10110}}
+    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{ with .meta.fields }}{{ $watchers := field "Watchers" . }}{{ if index . $watchers }}
   # watchers
-  customfield_10110: {{ range split "," (or .overrides.watchers "")}}
+  {{ $watchers }}: {{ range split "," (or $.overrides.watchers "")}}
     - name: {{.}}{{end}}
-    - name:{{end}}
+    - name:{{end}}{{end}}
   issuetype:
-    name: Epic`
+    name: {{ or .overrides.issuetype "Epic" }}`
 
 const defaultSubtaskTemplate = `{{/* create subtask template */ -}}
 fields:
@@ -537,11 +999,11 @@ fields:
   assignee:
     emailAddress: {{ or .overrides.assignee "" }}{{end}}{{if .meta.fields.reporter}}
   reporter:
-    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{if .meta.fields.customfield_10110}}
+    emailAddress: {{ or .overrides.reporter .overrides.login }}{{end}}{{ with .meta.fields }}{{ $watchers := field "Watchers" . }}{{ if index . $watchers }}
   # watchers
-  customfield_10110: {{ range split "," (or .overrides.watchers "")}}
+  {{ $watchers }}: {{ range split "," (or $.overrides.watchers "")}}
     - name: {{.}}{{end}}
-    - name:{{end}}
+    - name:{{end}}{{end}}
   issuetype:
     name: Sub-task
   parent:
@@ -0,0 +1,175 @@
+package jiracli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpicCreateTemplate(t *testing.T) {
+	// make sure we do not pick up any customized templates
+	dir, err := ioutil.TempDir("", "jira-templates")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer os.Chdir(cwd)
+	assert.NoError(t, os.Chdir(dir))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	// the create metadata for the Epic issue type
+	data := map[string]interface{}{
+		"overrides": map[string]interface{}{
+			"project":   "TEST",
+			"issuetype": "Epic",
+			"summary":   "Authentication",
+			"epic-name": "Auth",
+		},
+		"meta": map[string]interface{}{
+			"name": "Epic",
+			"fields": map[string]interface{}{
+				"summary":           map[string]interface{}{"name": "Summary", "required": true},
+				"customfield_10011": map[string]interface{}{"name": "Epic Name", "required": true},
+				"reporter":          map[string]interface{}{"name": "Reporter"},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, RunTemplate("epic-create", data, buf))
+
+	issue := map[string]interface{}{}
+	assert.NoError(t, ParseEditYAML(buf.Bytes(), &issue), buf.String())
+	fields, _ := issue["fields"].(map[string]interface{})
+	assert.Equal(t, "Auth", fields["customfield_10011"], buf.String())
+	assert.Equal(t, "Authentication", fields["summary"])
+	assert.Equal(t, map[string]interface{}{"name": "Epic"}, fields["issuetype"])
+	assert.Equal(t, map[string]interface{}{"key": "TEST"}, fields["project"])
+
+	// without the field on the screen there is nothing to set
+	data["meta"] = map[string]interface{}{
+		"name":   "Bug",
+		"fields": map[string]interface{}{"summary": map[string]interface{}{"name": "Summary"}},
+	}
+	buf.Reset()
+	assert.NoError(t, RunTemplate("epic-create", data, buf))
+	assert.NotContains(t, buf.String(), "customfield_10011")
+	assert.NotContains(t, buf.String(), "Epic Name")
+}
+
+// withTemplateDirs runs the test from a project directory under a temporary
+// HOME, the files are written to the .jira.d/templates directory of each.
+func withTemplateDirs(t *testing.T, home, project map[string]string, test func()) {
+	dir, err := ioutil.TempDir("", "jira-templates")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer os.Chdir(cwd)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	work := filepath.Join(dir, "src", "project")
+	for root, files := range map[string]map[string]string{dir: home, work: project} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, ".jira.d", "templates", "_partials"), 0755))
+		for name, content := range files {
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(root, ".jira.d", "templates", name), []byte(content), 0644))
+		}
+	}
+	assert.NoError(t, os.Chdir(work))
+	test()
+}
+
+func TestTemplatePartials(t *testing.T) {
+	home := map[string]string{
+		"_partials/user.tmpl":     `{{ .displayName }}`,
+		"_partials/status.tmpl":   `home {{ .name }}`,
+		"_partials/.hidden.tmpl":  `{{ broken`,
+		"_partials/signature.txt": "--\nsent from jira",
+	}
+	project := map[string]string{
+		"_partials/status.tmpl": `[{{ .name }}]`,
+		"summary": `{{ template "user" .fields.assignee }} {{ template "status" .fields.status }}
+{{ include "user" .fields.assignee | upper }}
+  {{ include "signature" . | indent 2 }}`,
+		"missing": `{{ include "missing" . }}`,
+	}
+	withTemplateDirs(t, home, project, func() {
+		partials, err := findPartials()
+		assert.NoError(t, err)
+		// the closest directory wins and hidden files are skipped
+		assert.Equal(t, map[string]string{
+			"user":      `{{ .displayName }}`,
+			"status":    `[{{ .name }}]`,
+			"signature": "--\nsent from jira",
+		}, partials)
+
+		data := map[string]interface{}{
+			"fields": map[string]interface{}{
+				"assignee": map[string]interface{}{"displayName": "Alice"},
+				"status":   map[string]interface{}{"name": "Done"},
+			},
+		}
+		buf := &bytes.Buffer{}
+		assert.NoError(t, RunTemplate("summary", data, buf))
+		assert.Equal(t, "Alice [Done]\nALICE\n  --\n  sent from jira", buf.String())
+
+		err = RunTemplate("missing", data, &bytes.Buffer{})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `no template "missing"`)
+		}
+	})
+
+	withTemplateDirs(t, map[string]string{"_partials/bad.tmpl": `{{ if }}`}, map[string]string{"ok": "ok"}, func() {
+		err := RunTemplate("ok", nil, &bytes.Buffer{})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `partial "bad"`)
+		}
+	})
+}
+
+func TestTemplateExtends(t *testing.T) {
+	content := `{{/* extends "view" */}}
+{{ define "view.description" }}description: {{ template "note" .fields.description }}
+{{ end }}`
+	project := map[string]string{
+		"_partials/note.tmpl": `({{ . }})`,
+		"short-view":          content,
+		"not-first":           "summary\n" + content,
+		"unknown":             `{{/* extends "nope" */}}`,
+	}
+	withTemplateDirs(t, nil, project, func() {
+		data := map[string]interface{}{
+			"key": "TEST-1",
+			"fields": map[string]interface{}{
+				"summary":     "Fix the login",
+				"description": "the old description",
+				"comment": map[string]interface{}{
+					"comments": []interface{}{map[string]interface{}{"body": "first comment", "created": "2020-03-02T10:00:00.000+0000", "author": map[string]interface{}{"displayName": "Bob"}}},
+				},
+			},
+		}
+		buf := &bytes.Buffer{}
+		assert.NoError(t, RunTemplate("short-view", data, buf))
+		// the overridden block is replaced and the others are kept
+		assert.Contains(t, buf.String(), "issue: TEST-1")
+		assert.Contains(t, buf.String(), "summary: Fix the login")
+		assert.Contains(t, buf.String(), "description: (the old description)")
+		assert.NotContains(t, buf.String(), "  the old description")
+		assert.Contains(t, buf.String(), "first comment")
+
+		// the comment must come first to extend the template
+		buf.Reset()
+		assert.NoError(t, RunTemplate("not-first", data, buf))
+		assert.NotContains(t, buf.String(), "issue: TEST-1")
+
+		err := RunTemplate("unknown", data, &bytes.Buffer{})
+		if assert.Error(t, err) {
+			assert.Equal(t, `Unable to extend "nope", it is not a built-in template`, err.Error())
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package jiracli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-jira/jira"
+	"github.com/mgutz/ansi"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// The terminal settings used by the template functions, these are set from
+// the global options by ConfigureTerminal before the command runs.
+var (
+	colorMode       = "auto"
+	hyperlinks      = true
+	colorTheme      = map[string]string{}
+	browseEndpoint  = ""
+	ansiEscapeCodes = regexp.MustCompile("\x1b\\[[0-9;]*m|\x1b\\]8;;[^\x1b]*\x1b\\\\")
+)
+
+// defaultTheme has the colors for the status categories, priorities and
+// issue types, it can be changed with the `theme` config property.  Statuses
+// are looked up by name, then by category ("new", "indeterminate" or "done").
+var defaultTheme = map[string]string{
+	"status.new":           "blue+b",
+	"status.indeterminate": "yellow+b",
+	"status.done":          "green+b",
+	"priority.blocker":     "red+b",
+	"priority.highest":     "red+b",
+	"priority.critical":    "red",
+	"priority.high":        "red",
+	"priority.major":       "yellow",
+	"priority.medium":      "yellow",
+	"priority.minor":       "green",
+	"priority.low":         "green",
+	"priority.trivial":     "blue",
+	"priority.lowest":      "blue",
+	"issuetype.bug":        "red",
+	"issuetype.story":      "green",
+	"issuetype.task":       "blue",
+	"issuetype.sub-task":   "cyan",
+	"issuetype.epic":       "magenta",
+	"markdown.heading":     "default+bu",
+	"markdown.code":        "cyan",
+	"markdown.quote":       "+h",
+}
+
+// ConfigureTerminal sets up the colors and hyperlinks from the global options.
+func ConfigureTerminal(globals *GlobalOptions) error {
+	switch globals.Color.Value {
+	case "auto", "always", "never":
+	default:
+		return fmt.Errorf("Invalid --color %q, must be one of auto, always or never", globals.Color.Value)
+	}
+	colorMode = globals.Color.Value
+	hyperlinks = globals.Hyperlinks.Value
+	colorTheme = globals.Theme
+	browseEndpoint = globals.Endpoint.Value
+	ansi.DisableColors(!colorOutput())
+	return nil
+}
+
+// colorOutput returns true when we should use colors and other escape codes,
+// "auto" will only use them for a terminal when NO_COLOR is not set.
+func colorOutput() bool {
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+func colorCode(color string) string {
+	if !colorOutput() || color == "" {
+		return ""
+	}
+	return ansi.ColorCode(color)
+}
+
+// themeColor returns the color code for the kind of value from the theme,
+// the value can be a name or an object with a name, like .fields.status
+func themeColor(kind string, value interface{}) string {
+	keys := []string{}
+	switch v := value.(type) {
+	case string:
+		keys = append(keys, v)
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			keys = append(keys, name)
+		}
+		if category, ok := v["statusCategory"].(map[string]interface{}); ok {
+			if key, ok := category["key"].(string); ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	for _, key := range keys {
+		key = kind + "." + strings.ToLower(key)
+		for _, theme := range []map[string]string{colorTheme, defaultTheme} {
+			for name, color := range theme {
+				if strings.ToLower(name) == key {
+					return colorCode(color)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// hyperlink returns the text as an OSC 8 terminal hyperlink to the url.
+func hyperlink(url, text string) string {
+	if url == "" || !hyperlinks || !colorOutput() {
+		return text
+	}
+	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
+}
+
+func issueURL(key string) string {
+	if browseEndpoint == "" || key == "" {
+		return ""
+	}
+	return jira.URLJoin(browseEndpoint, "browse", key)
+}
+
+// visibleLength is the length of the text without the escape codes
+func visibleLength(text string) int {
+	return utf8.RuneCountInString(ansiEscapeCodes.ReplaceAllString(text, ""))
+}
+
+var (
+	mdHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdList       = regexp.MustCompile(`^(\s*)([-*+]|\d+\.)\s+(.*)$`)
+	mdQuote      = regexp.MustCompile(`^>\s?(.*)$`)
+	mdRule       = regexp.MustCompile(`^\s*(-{3,}|\*{3,})\s*$`)
+	mdBold       = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalic     = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*)\*`)
+	mdStrike     = regexp.MustCompile(`~~([^~]+)~~`)
+	mdCode       = regexp.MustCompile("`([^`]+)`")
+	mdLink       = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdAngleLink  = regexp.MustCompile(`<((?:https?|mailto|ftp):[^>]+)>`)
+	mdCodeFences = "```"
+)
+
+// styleMarkdown formats the Markdown for the terminal, wrapped to the width.
+// Without colors the Markdown is only wrapped.
+func styleMarkdown(width int, content string) string {
+	styled := colorOutput()
+	reset := colorCode("reset")
+	out := []string{}
+	code := false
+	// ordered lists are renumbered, the Markdown may use "1." for every item
+	numbers := map[int]int{}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), mdCodeFences) {
+			code = !code
+			if !styled {
+				out = append(out, line)
+			}
+			continue
+		}
+		if code {
+			if styled {
+				line = "    " + themeColor("markdown", "code") + line + reset
+			}
+			out = append(out, line)
+			continue
+		}
+		if !styled {
+			if match := mdList.FindStringSubmatch(line); match != nil {
+				out = append(out, wrapIndent(line, width, strings.Repeat(" ", len(match[1])+len(match[2])+1))...)
+			} else {
+				out = append(out, wrapIndent(line, width, "")...)
+			}
+			continue
+		}
+
+		if !mdList.MatchString(line) && strings.TrimSpace(line) != "" {
+			numbers = map[int]int{}
+		}
+		switch {
+		case mdRule.MatchString(line):
+			out = append(out, strings.Repeat("─", width))
+		case mdHeading.MatchString(line):
+			match := mdHeading.FindStringSubmatch(line)
+			out = append(out, wrapIndent(themeColor("markdown", "heading")+styleInline(match[2])+reset, width, "")...)
+		case mdQuote.MatchString(line):
+			match := mdQuote.FindStringSubmatch(line)
+			for _, quoted := range wrapIndent(styleInline(match[1]), width-2, "") {
+				out = append(out, "│ "+themeColor("markdown", "quote")+quoted+reset)
+			}
+		case mdList.MatchString(line):
+			match := mdList.FindStringSubmatch(line)
+			bullet := "•"
+			if strings.HasSuffix(match[2], ".") {
+				numbers[len(match[1])]++
+				bullet = fmt.Sprintf("%d.", numbers[len(match[1])])
+			}
+			for depth := range numbers {
+				if depth > len(match[1]) {
+					delete(numbers, depth)
+				}
+			}
+			indent := match[1] + strings.Repeat(" ", utf8.RuneCountInString(bullet)+1)
+			out = append(out, wrapIndent(match[1]+bullet+" "+styleInline(match[3]), width, indent)...)
+		default:
+			out = append(out, wrapIndent(styleInline(line), width, "")...)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// styleInline replaces the Markdown text effects with escape codes.
+func styleInline(text string) string {
+	// keep the code spans away from the other effects
+	codes := []string{}
+	text = mdCode.ReplaceAllStringFunc(text, func(match string) string {
+		codes = append(codes, themeColor("markdown", "code")+mdCode.FindStringSubmatch(match)[1]+"\x1b[0m")
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+	text = mdLink.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdLink.FindStringSubmatch(match)
+		if hyperlinks && parts[1] != "" {
+			return hyperlink(parts[2], "\x1b[4m"+parts[1]+"\x1b[24m")
+		}
+		if parts[1] == "" {
+			return parts[2]
+		}
+		return fmt.Sprintf("%s (%s)", parts[1], parts[2])
+	})
+	text = mdAngleLink.ReplaceAllStringFunc(text, func(match string) string {
+		url := mdAngleLink.FindStringSubmatch(match)[1]
+		return hyperlink(url, url)
+	})
+	text = mdBold.ReplaceAllString(text, "\x1b[1m$1\x1b[22m")
+	text = mdItalic.ReplaceAllString(text, "$1\x1b[3m$2\x1b[23m")
+	text = mdStrike.ReplaceAllString(text, "\x1b[9m$1\x1b[29m")
+	for i, code := range codes {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), code, 1)
+	}
+	return text
+}
+
+// wrapIndent wraps the text to the width ignoring the escape codes, the
+// lines after the first are indented.
+func wrapIndent(text string, width int, indent string) []string {
+	if width <= len(indent)+1 || visibleLength(text) <= width {
+		return []string{text}
+	}
+	rest := strings.TrimLeft(text, " ")
+	line := text[:len(text)-len(rest)]
+	start := len(line)
+	lines := []string{}
+	for _, word := range strings.Split(rest, " ") {
+		switch {
+		case len(line) == start:
+			line += word
+		case visibleLength(line)+1+visibleLength(word) > width:
+			lines = append(lines, line)
+			line = indent + word
+			start = len(indent)
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,184 @@
+package jiracli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+// withTerminal runs the test with the color mode and hyperlinks forced, like
+// the --color and --hyperlinks options.
+func withTerminal(mode string, links bool, test func()) {
+	savedMode, savedLinks, savedTheme := colorMode, hyperlinks, colorTheme
+	defer func() {
+		colorMode, hyperlinks, colorTheme = savedMode, savedLinks, savedTheme
+	}()
+	colorMode, hyperlinks, colorTheme = mode, links, map[string]string{}
+	test()
+}
+
+func TestColorOutput(t *testing.T) {
+	withTerminal("always", true, func() {
+		assert.True(t, colorOutput())
+	})
+	withTerminal("never", true, func() {
+		assert.False(t, colorOutput())
+		assert.Equal(t, "", colorCode("red"))
+		assert.Equal(t, "https://jira", hyperlink("https://jira", "https://jira"))
+	})
+
+	saved, ok := os.LookupEnv("NO_COLOR")
+	os.Setenv("NO_COLOR", "")
+	defer func() {
+		if ok {
+			os.Setenv("NO_COLOR", saved)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	withTerminal("auto", true, func() {
+		// NO_COLOR only needs to be set, even when it is empty
+		assert.False(t, colorOutput())
+		assert.Equal(t, "", themeColor("status", "Done"))
+		assert.Equal(t, "**Done** [docs](https://x)", styleMarkdown(80, "**Done** [docs](https://x)"))
+	})
+	withTerminal("always", true, func() {
+		// --color always wins over NO_COLOR
+		assert.True(t, colorOutput())
+	})
+}
+
+func TestThemeColor(t *testing.T) {
+	withTerminal("always", true, func() {
+		colorTheme = map[string]string{"Status.In Review": "magenta"}
+		assert.Equal(t, ansi.ColorCode("magenta"), themeColor("status", "In Review"))
+		// statuses fall back to the category
+		assert.Equal(t, ansi.ColorCode("yellow+b"), themeColor("status", map[string]interface{}{
+			"name":           "Testing",
+			"statusCategory": map[string]interface{}{"key": "indeterminate"},
+		}))
+		assert.Equal(t, ansi.ColorCode("red"), themeColor("issuetype", map[string]interface{}{"name": "Bug"}))
+		assert.Equal(t, "", themeColor("priority", "Unknown"))
+	})
+}
+
+func TestHyperlink(t *testing.T) {
+	withTerminal("always", true, func() {
+		assert.Equal(t, "\x1b]8;;https://jira/browse/TEST-1\x1b\\TEST-1\x1b]8;;\x1b\\", hyperlink("https://jira/browse/TEST-1", "TEST-1"))
+		assert.Equal(t, "TEST-1", hyperlink("", "TEST-1"))
+		assert.Equal(t, "see \x1b]8;;https://x\x1b\\\x1b[4mdocs\x1b[24m\x1b]8;;\x1b\\", styleInline("see [docs](https://x)"))
+		assert.Equal(t, "\x1b]8;;https://x/y\x1b\\https://x/y\x1b]8;;\x1b\\", styleInline("<https://x/y>"))
+	})
+	withTerminal("always", false, func() {
+		assert.Equal(t, "TEST-1", hyperlink("https://jira/browse/TEST-1", "TEST-1"))
+		assert.Equal(t, "see docs (https://x) or https://y", styleInline("see [docs](https://x) or [](https://y)"))
+	})
+}
+
+func TestStyleInline(t *testing.T) {
+	withTerminal("always", false, func() {
+		code := ansi.ColorCode("cyan")
+		for text, expected := range map[string]string{
+			"plain text":               "plain text",
+			"**bold** and *italic*":    "\x1b[1mbold\x1b[22m and \x1b[3mitalic\x1b[23m",
+			"~~gone~~":                 "\x1b[9mgone\x1b[29m",
+			"run `**not bold**` first": "run " + code + "**not bold**\x1b[0m first",
+			"2 * 3 * 4":                "2 * 3 * 4",
+		} {
+			assert.Equal(t, expected, styleInline(text), text)
+		}
+	})
+}
+
+func TestStyleMarkdown(t *testing.T) {
+	withTerminal("always", false, func() {
+		reset := ansi.ColorCode("reset")
+		heading := ansi.ColorCode("default+bu")
+		code := ansi.ColorCode("cyan")
+		quote := ansi.ColorCode("+h")
+		for name, test := range map[string]struct {
+			content  string
+			expected []string
+		}{
+			"heading": {"## Steps", []string{heading + "Steps" + reset}},
+			"rule":    {"---", []string{strings.Repeat("─", 20)}},
+			"quote":   {"> quoted", []string{"│ " + quote + "quoted" + reset}},
+			"bullets": {"- one\n* two", []string{"• one", "• two"}},
+			"renumbered": {
+				"1. one\n1. two\n   1. nested\n   1. nested\n1. three",
+				[]string{"1. one", "2. two", "   1. nested", "   2. nested", "3. three"},
+			},
+			"restarted": {
+				"1. one\n\n1. two\ntext\n1. again",
+				[]string{"1. one", "", "2. two", "text", "1. again"},
+			},
+			"nested restarts": {
+				"1. one\n   1. a\n1. two\n   1. b",
+				[]string{"1. one", "   1. a", "2. two", "   1. b"},
+			},
+			"code fence": {
+				"```go\nx := **1**\n```\nafter",
+				[]string{"    " + code + "x := **1**" + reset, "after"},
+			},
+			"wrapped list": {
+				"- one two three four five six",
+				[]string{"• one two three four", "  five six"},
+			},
+			"wrapped bold": {
+				"**one two** three four five six",
+				[]string{"\x1b[1mone two\x1b[22m three four", "five six"},
+			},
+		} {
+			assert.Equal(t, test.expected, strings.Split(styleMarkdown(20, test.content), "\n"), name)
+		}
+	})
+
+	withTerminal("never", true, func() {
+		for name, test := range map[string]struct {
+			content  string
+			expected []string
+		}{
+			"unchanged": {
+				"## Steps\n1. one\n1. two\n> **quoted**",
+				[]string{"## Steps", "1. one", "1. two", "> **quoted**"},
+			},
+			"code fence": {
+				"```\na very long line of code that is not wrapped\n```",
+				[]string{"```", "a very long line of code that is not wrapped", "```"},
+			},
+			"wrapped": {
+				"one two three four five six",
+				[]string{"one two three four", "five six"},
+			},
+			"wrapped list": {
+				"10. one two three four five six",
+				[]string{"10. one two three", "    four five six"},
+			},
+		} {
+			assert.Equal(t, test.expected, strings.Split(styleMarkdown(20, test.content), "\n"), name)
+		}
+	})
+}
+
+func TestWrapIndent(t *testing.T) {
+	for name, test := range map[string]struct {
+		text     string
+		width    int
+		indent   string
+		expected []string
+	}{
+		"short":          {"one two", 10, "", []string{"one two"}},
+		"wrapped":        {"one two three four", 10, "", []string{"one two", "three four"}},
+		"indented":       {"- one two three four", 10, "  ", []string{"- one two", "  three", "  four"}},
+		"leading spaces": {"  one two three", 10, "  ", []string{"  one two", "  three"}},
+		"long word":      {"a verylongwordhere b", 8, "", []string{"a", "verylongwordhere", "b"}},
+		"escape codes":   {"\x1b[1mone\x1b[22m two \x1b]8;;https://x\x1b\\three\x1b]8;;\x1b\\", 9, "", []string{"\x1b[1mone\x1b[22m two", "\x1b]8;;https://x\x1b\\three\x1b]8;;\x1b\\"}},
+		"unicode":        {"héllo wörld ünïcode", 11, "", []string{"héllo wörld", "ünïcode"}},
+		"too narrow":     {"one two three", 2, "  ", []string{"one two three"}},
+	} {
+		assert.Equal(t, test.expected, wrapIndent(test.text, test.width, test.indent), name)
+	}
+}
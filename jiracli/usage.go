@@ -164,6 +164,12 @@ func CommandLine(fig *figtree.FigTree, o *oreo.Client) *kingpin.Application {
 func ParseCommandLine(app *kingpin.Application, args []string) {
 	// checking for default usage of `jira ISSUE-123` but need to allow
 	// for global options first like: `jira --user mothra ISSUE-123`
+	for _, arg := range args {
+		if arg == "--completion-bash" {
+			completing = true
+		}
+	}
+
 	ctx, err := app.ParseContext(args)
 	if err != nil && ctx == nil {
 		// This is an internal kingpin usage error, duplicate options/commands
@@ -6,10 +6,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"time"
 
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	yaml "gopkg.in/coryb/yaml.v2"
 
 	"github.com/coryb/figtree"
 )
@@ -108,6 +110,35 @@ func dateFormat(format string, content string) (string, error) {
 	return t.Format(format), nil
 }
 
+// ParseEditYAML will parse a document written by one of the edit templates
+// into the output, like an IssueUpdate.
+func ParseEditYAML(data []byte, output interface{}) error {
+	defer func(mapType, iface reflect.Type) {
+		yaml.DefaultMapType = mapType
+		yaml.IfaceType = iface
+	}(yaml.DefaultMapType, yaml.IfaceType)
+	yaml.DefaultMapType = reflect.TypeOf(map[string]interface{}{})
+	yaml.IfaceType = yaml.DefaultMapType.Elem()
+
+	// HACK HACK HACK we want to trim out all the yaml garbage that is not
+	// poplulated, like empty arrays, string values with only a newline,
+	// etc.  We need to do this because jira will reject json documents
+	// with empty arrays, or empty strings typically.  So here we process
+	// the data to a raw interface{} then we fixup the yaml parsed
+	// interface, then we serialize to a new yaml document ... then is
+	// parsed as the original document to populate the output struct.  Phew.
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	yamlFixup(&raw)
+	fixedYAML, err := yaml.Marshal(&raw)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(fixedYAML, output)
+}
+
 // this is a HACK to make yaml parsed documents to be serializable
 // to json, so prevent this:
 // json: unsupported type: map[interface {}]interface {}
@@ -0,0 +1,171 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ApplyOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Directory             string `yaml:"directory,omitempty" json:"directory,omitempty"`
+	IDLabelPrefix         string `yaml:"id-label-prefix,omitempty" json:"id-label-prefix,omitempty"`
+}
+
+func CmdApplyRegistry() *jiracli.CommandRegistryEntry {
+	opts := ApplyOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("apply"),
+		},
+		IDLabelPrefix: "spec:",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Create and update the issues to match the YAML specs in a directory",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdApplyUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdApply(o, globals, &opts)
+		},
+	}
+}
+
+func CmdApplyUsage(cmd *kingpin.CmdClause, opts *ApplyOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "project for the specs that do not set one").Short('p').StringVar(&opts.Project)
+	cmd.Flag("id-label-prefix", "prefix of the label used to match a spec ID to an issue").StringVar(&opts.IDLabelPrefix)
+	cmd.Arg("DIR", "directory with the issue YAML specs").Required().StringVar(&opts.Directory)
+	return nil
+}
+
+// CmdApply will run the actions from the plan for the specs, then send the
+// plan with the applied actions to the "apply" template.  When an action
+// fails the remaining actions are not run.
+func CmdApply(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ApplyOptions) error {
+	data, err := issuePlan(o, globals, opts.Directory, opts.Project, opts.IDLabelPrefix)
+	if err != nil {
+		return err
+	}
+
+	data.Applied = map[string]int{"create": 0, "update": 0, "transition": 0, "link": 0, "epic": 0}
+	var failed error
+	for _, action := range data.Actions {
+		if action.Issue == "" {
+			action.Issue = data.keys[action.Spec]
+		}
+		err := applyAction(o, globals, data, action)
+		if action.Issue != "" {
+			jiracli.InvalidateIssueCache(globals, action.Issue)
+		}
+		if err != nil {
+			failed = jiracli.CliError(fmt.Errorf("Unable to %s: %s", action.Message, err))
+			break
+		}
+		action.Applied = true
+		if !action.Skipped {
+			data.Applied[action.Action]++
+		}
+	}
+	if err := opts.PrintTemplate(data); err != nil {
+		return err
+	}
+	return failed
+}
+
+func applyAction(o *oreo.Client, globals *jiracli.GlobalOptions, data *planData, action *planAction) error {
+	endpoint := globals.Endpoint.Value
+	switch action.Action {
+	case "create":
+		resp, err := jira.CreateIssue(o, endpoint, &jiradata.IssueUpdate{Fields: action.update})
+		if err != nil {
+			return err
+		}
+		action.Issue = resp.Key
+		data.keys[action.Spec] = resp.Key
+		return nil
+	case "update":
+		return jira.EditIssue(o, endpoint, action.Issue, &jiradata.IssueUpdate{Fields: action.update})
+	case "transition":
+		return applyTransition(o, endpoint, action)
+	case "epic":
+		epic := data.refKey(action.Target)
+		if epic == "" {
+			return fmt.Errorf("the epic %q was not created", action.Target)
+		}
+		return jira.EpicAddIssues(o, endpoint, epic, &jiradata.EpicIssues{Issues: []string{action.Issue}})
+	case "link":
+		other := data.refKey(action.Target)
+		if other == "" {
+			return fmt.Errorf("the issue %q was not created", action.Target)
+		}
+		// the inward issue of the request has the outward description
+		link := &jiradata.LinkIssueRequest{
+			Type:         &jiradata.IssueLinkType{Name: action.LinkType},
+			InwardIssue:  &jiradata.IssueRef{Key: other},
+			OutwardIssue: &jiradata.IssueRef{Key: action.Issue},
+		}
+		if action.outward {
+			link.InwardIssue.Key, link.OutwardIssue.Key = action.Issue, other
+		}
+		return jira.LinkIssues(o, endpoint, link)
+	}
+	return fmt.Errorf("unknown action %q", action.Action)
+}
+
+// applyTransition will move the issue to the status, new issues may already
+// be in the status so the transition is skipped for them.
+func applyTransition(o *oreo.Client, endpoint string, action *planAction) error {
+	issue, err := jira.GetIssue(o, endpoint, action.Issue, &jira.IssueOptions{Fields: []string{"status"}})
+	if err != nil {
+		return err
+	}
+	if status, ok := issue.Fields["status"].(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(status["name"]), action.Target) {
+		action.Skipped = true
+		return nil
+	}
+
+	meta, err := jira.GetIssueTransitions(o, endpoint, action.Issue)
+	if err != nil {
+		return err
+	}
+	var transition *jiradata.Transition
+	for _, trans := range meta.Transitions {
+		if trans.To != nil && strings.EqualFold(trans.To.Name, action.Target) {
+			transition = trans
+			break
+		}
+	}
+	if transition == nil {
+		transition = meta.Transitions.Find(action.Target)
+	}
+	if transition == nil {
+		possible := []string{}
+		for _, trans := range meta.Transitions {
+			possible = append(possible, trans.Name)
+		}
+		return fmt.Errorf("no transition to %q, available: %s", action.Target, strings.Join(possible, ", "))
+	}
+
+	update := &jiradata.IssueUpdate{Transition: &jiradata.Transition{ID: transition.ID}}
+	// need to default the Resolution, usually Fixed works but sometime need Done
+	if resField, ok := transition.Fields["resolution"]; ok {
+		for _, allowedValueRaw := range resField.AllowedValues {
+			if allowedValue, ok := allowedValueRaw.(map[string]interface{}); ok {
+				if name := allowedValue["name"]; name == "Fixed" || name == "Done" {
+					update.Fields = map[string]interface{}{"resolution": map[string]interface{}{"name": name}}
+				}
+			}
+		}
+	}
+	return jira.TransitionIssue(o, endpoint, action.Issue, update)
+}
@@ -42,7 +42,7 @@ func CmdAssignUsage(cmd *kingpin.CmdClause, opts *AssignOptions) error {
 		}
 		return nil
 	}).Bool()
-	cmd.Arg("ISSUE", "issue to assign").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to assign", &opts.Issue)
 	cmd.Arg("ASSIGNEE", "email or display name of user to assign to issue").StringVar(&opts.Assignee)
 	return nil
 }
@@ -50,7 +50,7 @@ func CmdAssignUsage(cmd *kingpin.CmdClause, opts *AssignOptions) error {
 // CmdAssign will assign an issue to a user
 func CmdAssign(o *oreo.Client, globals *jiracli.GlobalOptions, opts *AssignOptions) error {
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
@@ -60,17 +60,11 @@ func CmdAssign(o *oreo.Client, globals *jiracli.GlobalOptions, opts *AssignOptio
 	assignFunc := jira.IssueAssign
 	if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 		if opts.Assignee != "" && opts.Assignee != "-1" {
-			users, err := jira.UserSearch(o, globals.Endpoint.Value, &jira.UserSearchOptions{
-				Query: opts.Assignee,
-			})
+			accountID, err := findUserAccountID(o, globals.Endpoint.Value, opts.Assignee)
 			if err != nil {
 				return err
 			}
-			if len(users) > 1 {
-				return fmt.Errorf("Found %d accounts for users with username %q", len(users), opts.Assignee)
-			} else if len(users) == 1 {
-				opts.Assignee = users[0].AccountID
-			}
+			opts.Assignee = accountID
 		}
 		assignFunc = jira.IssueAssignAccountID
 	}
@@ -44,7 +44,7 @@ func CmdAttachCreateUsage(cmd *kingpin.CmdClause, opts *AttachCreateOptions) err
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("saveFile", "Write attachment information as yaml to file").StringVar(&opts.SaveFile)
 	cmd.Flag("filename", "Filename to use for attachment").Short('f').StringVar(&opts.Filename)
-	cmd.Arg("ISSUE", "issue to assign").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to assign", &opts.Issue)
 	cmd.Arg("ATTACHMENT", "File to attach to issue, if not provided read from stdin").StringVar(&opts.Attachment)
 	return nil
 }
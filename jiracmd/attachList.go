@@ -40,7 +40,7 @@ func CmdAttachListRegistry() *jiracli.CommandRegistryEntry {
 func CmdAttachListUsage(cmd *kingpin.CmdClause, opts *AttachListOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "Issue id to lookup attachments").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "Issue id to lookup attachments", &opts.Issue)
 	return nil
 }
 
@@ -56,8 +56,8 @@ func CmdBlockUsage(cmd *kingpin.CmdClause, opts *BlockOptions) error {
 		}
 		return nil
 	}).String()
-	cmd.Arg("ISSUE", "issue that is blocked").Required().StringVar(&opts.OutwardIssue.Key)
-	cmd.Arg("BLOCKER", "blocker issue").Required().StringVar(&opts.InwardIssue.Key)
+	jiracli.IssueArgUsage(cmd, "issue that is blocked", &opts.OutwardIssue.Key)
+	jiracli.ArgAfterIssueUsage(cmd, "BLOCKER", "blocker issue", &opts.InwardIssue.Key).HintAction(jiracli.IssueHint())
 	return nil
 }
 
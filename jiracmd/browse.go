@@ -20,7 +20,7 @@ func CmdBrowseRegistry() *jiracli.CommandRegistryEntry {
 	return &jiracli.CommandRegistryEntry{
 		"Open issue in browser",
 		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
-			cmd.Arg("ISSUE", "Issue to browse to").Required().StringVar(&opts.Issue)
+			jiracli.IssueArgUsage(cmd, "Issue to browse to", &opts.Issue)
 			jiracli.LoadConfigs(cmd, fig, &opts)
 			return nil
 		},
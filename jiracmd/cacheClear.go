@@ -0,0 +1,34 @@
+package jiracmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdCacheClearRegistry() *jiracli.CommandRegistryEntry {
+	return &jiracli.CommandRegistryEntry{
+		"Remove the cached metadata and completions",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdCacheClear(globals)
+		},
+	}
+}
+
+// CmdCacheClear will remove everything stored in ~/.jira.d/cache
+func CmdCacheClear(globals *jiracli.GlobalOptions) error {
+	if err := os.RemoveAll(jiracli.CacheDir()); err != nil {
+		return err
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK cleared %s\n", jiracli.CacheDir())
+	}
+	return nil
+}
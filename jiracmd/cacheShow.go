@@ -0,0 +1,36 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdCacheShowRegistry() *jiracli.CommandRegistryEntry {
+	opts := jiracli.CommonOptions{
+		Template: figtree.NewStringOption("cache-show"),
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the metadata responses stored in the cache",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			jiracli.TemplateUsage(cmd, &opts)
+			jiracli.GJsonQueryUsage(cmd, &opts)
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdCacheShow(globals, &opts)
+		},
+	}
+}
+
+// CmdCacheShow will send the cached metadata entries to the "cache-show" template
+func CmdCacheShow(globals *jiracli.GlobalOptions, opts *jiracli.CommonOptions) error {
+	data, err := jiracli.MetaCache(globals).Entries()
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -49,7 +49,7 @@ func CmdCommentUsage(cmd *kingpin.CmdClause, opts *CommentOptions) error {
 		opts.Overrides["comment"] = jiracli.FlagValue(ctx, "comment")
 		return nil
 	}).String()
-	cmd.Arg("ISSUE", "issue id to update").StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue id to update", &opts.Issue)
 	return nil
 }
 
@@ -0,0 +1,236 @@
+package jiracmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type CompletionOptions struct {
+	Shell string `yaml:"shell,omitempty" json:"shell,omitempty"`
+}
+
+func CmdCompletionRegistry() *jiracli.CommandRegistryEntry {
+	opts := CompletionOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Print shell completion script",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			return CmdCompletionUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdCompletion(&opts)
+		},
+	}
+}
+
+func CmdCompletionUsage(cmd *kingpin.CmdClause, opts *CompletionOptions) error {
+	cmd.Arg("SHELL", "Shell to print completion script for: bash, zsh or fish").Required().EnumVar(&opts.Shell, "bash", "zsh", "fish")
+	return nil
+}
+
+// the partially typed word is not passed to jira, kingpin will consider a
+// positional argument satisfied once it has any value so we would only ever
+// complete the next argument.  The shell filters the results on the word.
+const bashCompletion = `_jira_bash_autocomplete() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local word=""
+    if [[ "$cur" == -* ]]; then
+        word="$cur"
+    fi
+    local IFS=$'\n'
+    local opts=$( "${COMP_WORDS[0]}" --completion-bash "${COMP_WORDS[@]:1:$COMP_CWORD-1}" "$word" 2>/dev/null )
+    COMPREPLY=( $(compgen -W "$opts" -- "$cur") )
+    if [[ ${#COMPREPLY[@]} -gt 0 ]]; then
+        COMPREPLY=( $(printf '%q\n' "${COMPREPLY[@]}") )
+    fi
+    return 0
+}
+complete -o default -F _jira_bash_autocomplete jira
+`
+
+const zshCompletion = `#compdef jira
+autoload -U +X bashcompinit && bashcompinit
+` + bashCompletion
+
+const fishCompletion = `function __jira_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    set -l cur (commandline -ct)
+    if string match -q -- '-*' $cur
+        set args $args $cur
+    else
+        set args $args ''
+    end
+    jira --completion-bash $args 2>/dev/null
+end
+complete -c jira -f -a '(__jira_complete)'
+`
+
+// CmdCompletion will print the completion script for the shell, the scripts
+// call back to jira with --completion-bash to get the suggestions.
+func CmdCompletion(opts *CompletionOptions) error {
+	switch opts.Shell {
+	case "bash":
+		os.Stdout.WriteString(bashCompletion)
+	case "zsh":
+		os.Stdout.WriteString(zshCompletion)
+	case "fish":
+		os.Stdout.WriteString(fishCompletion)
+	}
+	return nil
+}
+
+// completionIssueLimit is the number of issues from the `default-query` that
+// are used to suggest transitions and labels
+const completionIssueLimit = 10
+
+// transitionHint suggests the transitions available for the issues found with
+// the `default-query`.
+func transitionHint() kingpin.HintAction {
+	return jiracli.CompletionHint(func(o *oreo.Client, globals *jiracli.GlobalOptions, config *jiracli.CompletionConfig) ([]string, error) {
+		issues, err := jiracli.IssueCompletions(o, globals, config)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) > completionIssueLimit {
+			issues = issues[:completionIssueLimit]
+		}
+		results := []string{}
+		for _, issue := range issues {
+			names, err := jiracli.CachedCompletions(globals, "transitions:"+issue, func() ([]string, error) {
+				meta, err := jira.GetIssueTransitions(o, globals.Endpoint.Value, issue)
+				if err != nil {
+					return nil, err
+				}
+				names := []string{}
+				for _, trans := range meta.Transitions {
+					names = append(names, trans.Name)
+				}
+				return names, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, names...)
+		}
+		return uniqueSorted(results), nil
+	})
+}
+
+// labelHint suggests the labels on the issue if it has been provided,
+// otherwise the labels used on the issues found with the `default-query`.
+func labelHint(issue *string) kingpin.HintAction {
+	return jiracli.CompletionHint(func(o *oreo.Client, globals *jiracli.GlobalOptions, config *jiracli.CompletionConfig) ([]string, error) {
+		query := globals.DefaultQuery.Value
+		if query == "" {
+			query = jiracli.DefaultIssueQuery
+		}
+		if issue != nil && *issue != "" {
+			query = fmt.Sprintf("key = %s", jiracli.FormatIssue(*issue, config.Project))
+		}
+		return jiracli.CachedCompletions(globals, "labels:"+query, func() ([]string, error) {
+			results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+				Query:       query,
+				QueryFields: "labels",
+				MaxResults:  100,
+			})
+			if err != nil {
+				return nil, err
+			}
+			labels := []string{}
+			for _, issue := range results.Issues {
+				if values, ok := issue.Fields["labels"].([]interface{}); ok {
+					for _, value := range values {
+						if label, ok := value.(string); ok {
+							labels = append(labels, label)
+						}
+					}
+				}
+			}
+			return uniqueSorted(labels), nil
+		})
+	})
+}
+
+// componentHint suggests the components for the project, or the configured
+// project if the project has not been provided.
+func componentHint(project *string) kingpin.HintAction {
+	return jiracli.CompletionHint(func(o *oreo.Client, globals *jiracli.GlobalOptions, config *jiracli.CompletionConfig) ([]string, error) {
+		key := completionProject(project, config)
+		if key == "" {
+			return nil, nil
+		}
+		return jiracli.CachedCompletions(globals, "components:"+key, func() ([]string, error) {
+			components, err := jira.GetProjectComponents(o, globals.Endpoint.Value, key)
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, component := range *components {
+				names = append(names, component.Name)
+			}
+			return uniqueSorted(names), nil
+		})
+	})
+}
+
+// issueTypeHint suggests the issue types for the project, or the configured
+// project if the project has not been provided.
+func issueTypeHint(project *string) kingpin.HintAction {
+	return jiracli.CompletionHint(func(o *oreo.Client, globals *jiracli.GlobalOptions, config *jiracli.CompletionConfig) ([]string, error) {
+		key := completionProject(project, config)
+		if key == "" {
+			return nil, nil
+		}
+		return jiracli.CachedCompletions(globals, "issuetypes:"+key, func() ([]string, error) {
+			meta, err := jira.GetIssueCreateMetaProject(o, globals.Endpoint.Value, key)
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, issueType := range meta.IssueTypes {
+				names = append(names, issueType.Name)
+			}
+			return uniqueSorted(names), nil
+		})
+	})
+}
+
+// namedQueryHint suggests the names from the `queries` configuration.
+func namedQueryHint() kingpin.HintAction {
+	return jiracli.CompletionHint(func(o *oreo.Client, globals *jiracli.GlobalOptions, config *jiracli.CompletionConfig) ([]string, error) {
+		names := []string{}
+		for name := range config.Queries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	})
+}
+
+func completionProject(project *string, config *jiracli.CompletionConfig) string {
+	if project != nil && *project != "" {
+		return *project
+	}
+	return config.Project
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	results := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			results = append(results, value)
+		}
+	}
+	sort.Strings(results)
+	return results
+}
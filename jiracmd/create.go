@@ -53,7 +53,7 @@ func CmdCreateUsage(cmd *kingpin.CmdClause, opts *CreateOptions) error {
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
 	cmd.Flag("project", "project to create issue in").Short('p').StringVar(&opts.Project)
 	cmd.Flag("summary", "Summary of the issue").Short('s').StringVar(&opts.Summary)
-	cmd.Flag("issuetype", "issuetype in to create").Short('i').StringVar(&opts.IssueType)
+	cmd.Flag("issuetype", "issuetype in to create").Short('i').HintAction(issueTypeHint(&opts.Project)).StringVar(&opts.IssueType)
 	cmd.Flag("comment", "Comment message for issue").Short('m').PreAction(func(ctx *kingpin.ParseContext) error {
 		opts.Overrides["comment"] = jiracli.FlagValue(ctx, "comment")
 		return nil
@@ -66,8 +66,9 @@ func CmdCreateUsage(cmd *kingpin.CmdClause, opts *CreateOptions) error {
 // CmdCreate sends the create-metadata to the "create" template for editing, then
 // will parse the edited document as YAML and submit the document to jira.
 func CmdCreate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CreateOptions) error {
+	cache := jiracli.CacheClient(o, globals)
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(cache, globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
@@ -79,10 +80,10 @@ func CmdCreate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CreateOptio
 		Overrides map[string]string   `yaml:"overrides" json:"overrides"`
 	}
 
-	if err := defaultIssueType(o, globals.Endpoint.Value, &opts.Project, &opts.IssueType); err != nil {
+	if err := defaultIssueType(cache, globals.Endpoint.Value, &opts.Project, &opts.IssueType); err != nil {
 		return err
 	}
-	createMeta, err := jira.GetIssueCreateMetaIssueType(o, globals.Endpoint.Value, opts.Project, opts.IssueType)
+	createMeta, err := jira.GetIssueCreateMetaIssueType(cache, globals.Endpoint.Value, opts.Project, opts.IssueType)
 	if err != nil {
 		return err
 	}
@@ -104,11 +105,23 @@ func CmdCreate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CreateOptio
 	fnameOptsFile = opts.File.String()
 	if fnameOptsFile != "" {
 		err = jiracli.ReadYmlInputFile(&opts.CommonOptions, &input, &issueUpdate, func() error {
+			if err := jiracli.ResolveIssueUpdate(&issueUpdate, createMeta.Fields, opts.Overrides); err != nil {
+				return err
+			}
+			if err := jiracli.CheckIssueUpdate(&issueUpdate, createMeta.Fields, "create"); err != nil {
+				return err
+			}
 			issueResp, err = jira.CreateIssue(o, globals.Endpoint.Value, &issueUpdate)
 			return err
 		})
 	} else {
 		err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, func() error {
+			if err := jiracli.ResolveIssueUpdate(&issueUpdate, createMeta.Fields, opts.Overrides); err != nil {
+				return err
+			}
+			if err := jiracli.CheckIssueUpdate(&issueUpdate, createMeta.Fields, "create"); err != nil {
+				return err
+			}
 			if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 				err := fixGDPRUserFields(o, globals.Endpoint.Value, createMeta.Fields, issueUpdate.Fields)
 				if err != nil {
@@ -150,14 +163,14 @@ func CmdCreate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CreateOptio
 	return nil
 }
 
-func defaultIssueType(o *oreo.Client, endpoint string, project, issuetype *string) error {
+func defaultIssueType(ua jira.HttpClient, endpoint string, project, issuetype *string) error {
 	if project == nil || *project == "" {
 		return fmt.Errorf("Project undefined, please use --project argument or set the `project` config property")
 	}
 	if issuetype != nil && *issuetype != "" {
 		return nil
 	}
-	projectMeta, err := jira.GetIssueCreateMetaProject(o, endpoint, *project)
+	projectMeta, err := jira.GetIssueCreateMetaProject(ua, endpoint, *project)
 	if err != nil {
 		return err
 	}
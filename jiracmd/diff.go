@@ -0,0 +1,391 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type DiffOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	At                    string `yaml:"at,omitempty" json:"at,omitempty"`
+}
+
+// diffData is sent to the "diff" template, From and To describe the two
+// versions of the issue being compared.
+type diffData struct {
+	Issue  string       `json:"issue" yaml:"issue"`
+	From   string       `json:"from" yaml:"from"`
+	To     string       `json:"to" yaml:"to"`
+	Fields []*diffField `json:"fields" yaml:"fields"`
+}
+
+type diffField struct {
+	ID    string      `json:"id" yaml:"id"`
+	Name  string      `json:"name" yaml:"name"`
+	Lines []*diffLine `json:"lines" yaml:"lines"`
+}
+
+type diffLine struct {
+	// Op is "-" for a removed line, "+" for an added line or " " when the
+	// line is unchanged
+	Op   string `json:"op" yaml:"op"`
+	Text string `json:"text" yaml:"text"`
+}
+
+func CmdDiffRegistry() *jiracli.CommandRegistryEntry {
+	opts := DiffOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("diff"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Show the changes to an issue since a time, or the changes an edit file would make",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdDiffUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdDiff(o, globals, &opts)
+		},
+	}
+}
+
+func CmdDiffUsage(cmd *kingpin.CmdClause, opts *DiffOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("at", "Compare with the issue at this time, like 2006-01-02, \"2006-01-02 15:04\" or a duration like 36h").StringVar(&opts.At)
+	cmd.Flag("file", "Compare with a YAML file in the edit template format").SetValue(&opts.File)
+	jiracli.IssueArgUsage(cmd, "issue id to diff", &opts.Issue)
+	return nil
+}
+
+// CmdDiff will compare the live issue with the issue rebuilt from the
+// changelog at a time, or with the changes in an edit file, and send the
+// changed fields to the "diff" template.
+func CmdDiff(o *oreo.Client, globals *jiracli.GlobalOptions, opts *DiffOptions) error {
+	if (opts.At == "") == (opts.File.Value == "") {
+		return jiracli.CliError(fmt.Errorf("One of --at or --file is required"))
+	}
+
+	issueOpts := &jira.IssueOptions{}
+	if opts.At != "" {
+		issueOpts.Expand = []string{"changelog"}
+	}
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, issueOpts)
+	if err != nil {
+		return err
+	}
+	// the expanded changelog only has the first 100 histories, fetch the
+	// rest so the older values are not rebuilt from a partial changelog
+	if changelog := issue.Changelog; changelog != nil && changelog.Total > len(changelog.Histories) {
+		histories, err := jira.GetIssueChangelog(o, globals.Endpoint.Value, issue.Key)
+		if err != nil {
+			return jiracli.CliError(fmt.Errorf("The changelog for %s has %d changes but only %d were returned, and the rest could not be fetched: %s", issue.Key, changelog.Total, len(changelog.Histories), err))
+		}
+		if len(histories) < changelog.Total {
+			return jiracli.CliError(fmt.Errorf("The changelog for %s has %d changes but only %d were returned", issue.Key, changelog.Total, len(histories)))
+		}
+		changelog.Histories = histories
+	}
+
+	names := map[string]string{}
+	if fields, err := jira.GetFields(jiracli.CacheClient(o, globals), globals.Endpoint.Value); err != nil {
+		log.Debugf("Unable to fetch the field names: %s", err)
+	} else {
+		for _, field := range fields {
+			names[field.ID] = field.Name
+		}
+	}
+
+	data := &diffData{Issue: issue.Key}
+	var changes map[string][2][]string
+	if opts.At != "" {
+		at, err := diffTime(opts.At, time.Now())
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		data.From = fmt.Sprintf("%s at %s", issue.Key, at.Format("2006-01-02 15:04:05 -0700"))
+		data.To = fmt.Sprintf("%s (live)", issue.Key)
+		changes = diffHistory(issue, at, names)
+	} else {
+		content, err := ioutil.ReadFile(opts.File.Value)
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		update := jiradata.IssueUpdate{}
+		if err := jiracli.ParseEditYAML(content, &update); err != nil {
+			return jiracli.CliError(fmt.Errorf("Unable to parse %s: %s", opts.File.Value, err))
+		}
+		if err := jiracli.ResolveIssueUpdate(&update, nil, nil); err != nil {
+			return jiracli.CliError(err)
+		}
+		data.From = fmt.Sprintf("%s (live)", issue.Key)
+		data.To = opts.File.Value
+		changes = diffUpdate(issue, &update)
+	}
+
+	data.Fields = []*diffField{}
+	for id, change := range changes {
+		lines := diffLines(change[0], change[1])
+		changed := false
+		for _, line := range lines {
+			changed = changed || line.Op != " "
+		}
+		if !changed {
+			continue
+		}
+		name := id
+		if names[id] != "" {
+			name = names[id]
+		}
+		data.Fields = append(data.Fields, &diffField{ID: id, Name: name, Lines: lines})
+	}
+	sort.Slice(data.Fields, func(i, j int) bool {
+		return data.Fields[i].Name < data.Fields[j].Name
+	})
+	return opts.PrintTemplate(data)
+}
+
+// diffTime parses the --at value as a date, a date and time in the local time
+// zone, or a duration before now.
+func diffTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid time %q, use a date like 2006-01-02, \"2006-01-02 15:04\" or a duration like 36h", value)
+}
+
+// diffHistory rebuilds the fields changed since the time by undoing the
+// changelog from the live issue, returning the old and live values by field.
+func diffHistory(issue *jiradata.Issue, at time.Time, names map[string]string) map[string][2][]string {
+	ids := map[string]string{}
+	for id, name := range names {
+		ids[strings.ToLower(name)] = id
+	}
+
+	histories := []*jiradata.ChangeHistory{}
+	if issue.Changelog != nil {
+		for _, history := range issue.Changelog.Histories {
+			if reportTime(history.Created).After(at) {
+				histories = append(histories, history)
+			}
+		}
+	}
+	// undo the newest changes first, the changelog is oldest first so
+	// reverse it to keep the changes made at the same time in order
+	for i, j := 0, len(histories)-1; i < j; i, j = i+1, j-1 {
+		histories[i], histories[j] = histories[j], histories[i]
+	}
+	sort.SliceStable(histories, func(i, j int) bool {
+		return reportTime(histories[i].Created).After(reportTime(histories[j].Created))
+	})
+
+	changes := map[string][2][]string{}
+	lists := map[string]bool{}
+	for _, history := range histories {
+		for _, item := range history.Items {
+			id := item.FieldID
+			if id == "" {
+				id = item.Field
+				if found, ok := ids[strings.ToLower(item.Field)]; ok {
+					id = found
+				}
+			}
+			if _, ok := changes[id]; !ok {
+				live, found := issue.Fields[id]
+				current := diffValues(live, nil)
+				if !found {
+					// we can only tell the value from the newest change
+					current = diffSplit(item.ToString)
+				}
+				_, lists[id] = live.([]interface{})
+				changes[id] = [2][]string{current, current}
+			}
+			past := changes[id][0]
+			switch {
+			case !lists[id]:
+				past = diffSplit(item.FromString)
+			case item.FromString == "":
+				past = diffRemove(past, item.ToString)
+			case item.ToString == "":
+				past = append(diffRemove(past, item.FromString), item.FromString)
+			case diffContains(past, item.ToString):
+				past = append(diffRemove(past, item.ToString), item.FromString)
+			default:
+				// fields like labels have the whole list in the change
+				past = strings.Fields(item.FromString)
+			}
+			changes[id] = [2][]string{past, changes[id][1]}
+		}
+	}
+	return changes
+}
+
+// diffUpdate returns the live and updated values for the fields set in the
+// fields section of the update, or changed by the update operations.
+func diffUpdate(issue *jiradata.Issue, update *jiradata.IssueUpdate) map[string][2][]string {
+	changes := map[string][2][]string{}
+	for id, value := range update.Fields {
+		// only compare the properties in the file, so an assignee with
+		// just an emailAddress is not different from the live assignee
+		changes[id] = [2][]string{diffValues(issue.Fields[id], value), diffValues(value, value)}
+	}
+	for id, operations := range update.Update {
+		current := []string{}
+		if change, ok := changes[id]; ok {
+			current = change[1]
+		} else if id != "comment" {
+			current = diffValues(issue.Fields[id], nil)
+		}
+		updated := append([]string{}, current...)
+		for _, operation := range operations {
+			for op, value := range operation {
+				switch op {
+				case "set", "edit":
+					updated = diffValues(value, value)
+				case "add":
+					if comment, ok := value.(map[string]interface{}); ok && id == "comment" {
+						value = comment["body"]
+					}
+					updated = append(updated, diffValues(value, value)...)
+				case "remove":
+					for _, removed := range diffValues(value, value) {
+						updated = diffRemove(updated, removed)
+					}
+				}
+			}
+		}
+		changes[id] = [2][]string{current, updated}
+	}
+	return changes
+}
+
+// diffValues returns the value as lines of text to compare, lists have one
+// item per line.  When like is set only its properties are used from objects.
+func diffValues(value, like interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		if list, ok := like.([]interface{}); ok && len(list) > 0 {
+			like = list[0]
+		}
+		lines := []string{}
+		for _, item := range v {
+			lines = append(lines, diffValues(item, like)...)
+		}
+		return lines
+	case map[string]interface{}:
+		keys := []string{}
+		if props, ok := like.(map[string]interface{}); ok {
+			for key := range props {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+		} else {
+			for _, key := range []string{"displayName", "name", "value", "key", "accountId", "id"} {
+				if _, ok := v[key]; ok {
+					keys = []string{key}
+					break
+				}
+			}
+		}
+		if len(keys) == 1 {
+			return diffValues(v[keys[0]], nil)
+		}
+		parts := []string{}
+		for _, key := range keys {
+			parts = append(parts, fmt.Sprintf("%s: %s", key, strings.Join(diffValues(v[key], nil), " ")))
+		}
+		return []string{strings.Join(parts, ", ")}
+	case string:
+		return diffSplit(v)
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+func diffSplit(text string) []string {
+	text = strings.TrimRight(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
+
+func diffContains(lines []string, text string) bool {
+	for _, line := range lines {
+		if line == text {
+			return true
+		}
+	}
+	return false
+}
+
+func diffRemove(lines []string, text string) []string {
+	kept := []string{}
+	for _, line := range lines {
+		if line != text {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// diffLines returns the lines to change from into to, using the longest
+// common subsequence of lines.
+func diffLines(from, to []string) []*diffLine {
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	lines := []*diffLine{}
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			lines = append(lines, &diffLine{Op: " ", Text: from[i]})
+			i++
+			j++
+		case j == len(to) || (i < len(from) && common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, &diffLine{Op: "-", Text: from[i]})
+			i++
+		default:
+			lines = append(lines, &diffLine{Op: "+", Text: to[j]})
+			j++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,170 @@
+package jiracmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := func(diff []*diffLine) []string {
+		out := []string{}
+		for _, line := range diff {
+			out = append(out, line.Op+line.Text)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{}, lines(diffLines(nil, nil)))
+	assert.Equal(t, []string{" a", " b"}, lines(diffLines([]string{"a", "b"}, []string{"a", "b"})))
+	assert.Equal(t, []string{"+a", "+b"}, lines(diffLines(nil, []string{"a", "b"})))
+	assert.Equal(t, []string{"-a", "-b"}, lines(diffLines([]string{"a", "b"}, []string{})))
+	assert.Equal(t,
+		[]string{" one", "-two", "+2", " three", "+four"},
+		lines(diffLines([]string{"one", "two", "three"}, []string{"one", "2", "three", "four"})),
+	)
+	// the longest common lines are kept
+	assert.Equal(t,
+		[]string{"-x", " a", " b", "-y", " c", "+z"},
+		lines(diffLines([]string{"x", "a", "b", "y", "c"}, []string{"a", "b", "c", "z"})),
+	)
+}
+
+func TestDiffTime(t *testing.T) {
+	now := time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC)
+	for value, expected := range map[string]time.Time{
+		"36h":                       now.Add(-36 * time.Hour),
+		"90m":                       now.Add(-90 * time.Minute),
+		"2020-03-01T10:30:00Z":      time.Date(2020, 3, 1, 10, 30, 0, 0, time.UTC),
+		"2020-03-01T10:30:00+02:00": time.Date(2020, 3, 1, 8, 30, 0, 0, time.UTC),
+		"2020-03-01 10:30:15":       time.Date(2020, 3, 1, 10, 30, 15, 0, time.Local),
+		"2020-03-01T10:30:15":       time.Date(2020, 3, 1, 10, 30, 15, 0, time.Local),
+		"2020-03-01 10:30":          time.Date(2020, 3, 1, 10, 30, 0, 0, time.Local),
+		"2020-03-01":                time.Date(2020, 3, 1, 0, 0, 0, 0, time.Local),
+	} {
+		at, err := diffTime(value, now)
+		assert.NoError(t, err, value)
+		assert.True(t, expected.Equal(at), "%s: expected %s got %s", value, expected, at)
+	}
+
+	for _, value := range []string{"", "yesterday", "03/01/2020", "2020-03-01 10"} {
+		_, err := diffTime(value, now)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	for name, test := range map[string]struct {
+		value    interface{}
+		like     interface{}
+		expected []string
+	}{
+		"nil":              {nil, nil, []string{}},
+		"empty string":     {"", nil, []string{}},
+		"multiline string": {"one\r\ntwo\n\n", nil, []string{"one", "two"}},
+		"number":           {3.5, nil, []string{"3.5"}},
+		"bool":             {true, nil, []string{"true"}},
+		"list":             {[]interface{}{"a", "b"}, nil, []string{"a", "b"}},
+		"user": {
+			map[string]interface{}{"displayName": "Alice", "name": "alice", "accountId": "u1"},
+			nil,
+			[]string{"Alice"},
+		},
+		"option": {
+			map[string]interface{}{"value": "Red", "id": "10001"},
+			nil,
+			[]string{"Red"},
+		},
+		"list of components": {
+			[]interface{}{map[string]interface{}{"name": "api", "id": "1"}, map[string]interface{}{"name": "cli", "id": "2"}},
+			nil,
+			[]string{"api", "cli"},
+		},
+		"like one property": {
+			map[string]interface{}{"displayName": "Alice", "emailAddress": "alice@example.com"},
+			map[string]interface{}{"emailAddress": "bob@example.com"},
+			[]string{"alice@example.com"},
+		},
+		"like several properties": {
+			map[string]interface{}{"name": "Blocker", "id": "1", "iconUrl": "x"},
+			map[string]interface{}{"name": "", "id": ""},
+			[]string{"id: 1, name: Blocker"},
+		},
+		"like a list": {
+			[]interface{}{map[string]interface{}{"name": "api", "id": "1"}},
+			[]interface{}{map[string]interface{}{"id": "1"}},
+			[]string{"1"},
+		},
+	} {
+		assert.Equal(t, test.expected, diffValues(test.value, test.like), name)
+	}
+}
+
+func TestDiffHistory(t *testing.T) {
+	change := func(created string, items ...*jiradata.ChangeItem) *jiradata.ChangeHistory {
+		return &jiradata.ChangeHistory{Created: created, Items: items}
+	}
+	issue := &jiradata.Issue{
+		Key: "TEST-1",
+		Fields: map[string]interface{}{
+			"summary":           "Fix the login page",
+			"status":            map[string]interface{}{"name": "Done"},
+			"labels":            []interface{}{"ui", "urgent"},
+			"components":        []interface{}{map[string]interface{}{"name": "web"}},
+			"customfield_10010": 5.0,
+		},
+		Changelog: &jiradata.Changelog{
+			Histories: jiradata.Histories{
+				// before the time, so not undone
+				change("2020-03-01T09:00:00.000+0000",
+					&jiradata.ChangeItem{Field: "summary", FieldID: "summary", FromString: "Login", ToString: "Login page"},
+				),
+				// the newest change is listed first to check the sorting
+				change("2020-03-03T09:00:00.000+0000",
+					&jiradata.ChangeItem{Field: "status", FieldID: "status", FromString: "In Progress", ToString: "Done"},
+					&jiradata.ChangeItem{Field: "labels", FieldID: "labels", FromString: "ui", ToString: "ui urgent"},
+				),
+				change("2020-03-02T09:00:00.000+0000",
+					&jiradata.ChangeItem{Field: "summary", FieldID: "summary", FromString: "Login page", ToString: "Fix the login page"},
+					&jiradata.ChangeItem{Field: "status", FieldID: "status", FromString: "Open", ToString: "In Progress"},
+					&jiradata.ChangeItem{Field: "Component", FieldID: "components", FromString: "", ToString: "web"},
+					// older changes only have the field name
+					&jiradata.ChangeItem{Field: "Story Points", FromString: "3", ToString: "5"},
+					// a field the issue does not return
+					&jiradata.ChangeItem{Field: "Sprint", FieldID: "customfield_10020", FromString: "Sprint 1", ToString: "Sprint 2"},
+				),
+			},
+		},
+	}
+	names := map[string]string{"customfield_10010": "Story Points", "customfield_10020": "Sprint"}
+
+	at := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[string][2][]string{
+		"summary":           {{"Login page"}, {"Fix the login page"}},
+		"status":            {{"Open"}, {"Done"}},
+		"labels":            {{"ui"}, {"ui", "urgent"}},
+		"components":        {{}, {"web"}},
+		"customfield_10010": {{"3"}, {"5"}},
+		"customfield_10020": {{"Sprint 1"}, {"Sprint 2"}},
+	}, diffHistory(issue, at, names))
+
+	// only the changes after the time are undone
+	at = time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[string][2][]string{
+		"status": {{"In Progress"}, {"Done"}},
+		"labels": {{"ui"}, {"ui", "urgent"}},
+	}, diffHistory(issue, at, names))
+
+	// changes made at the same time are undone from the last one
+	issue.Changelog.Histories = jiradata.Histories{
+		change("2020-03-03T09:00:00.000+0000", &jiradata.ChangeItem{FieldID: "summary", FromString: "one", ToString: "two"}),
+		change("2020-03-03T09:00:00.000+0000", &jiradata.ChangeItem{FieldID: "summary", FromString: "two", ToString: "Fix the login page"}),
+	}
+	assert.Equal(t, map[string][2][]string{
+		"summary": {{"one"}, {"Fix the login page"}},
+	}, diffHistory(issue, at, names))
+
+	assert.Empty(t, diffHistory(&jiradata.Issue{Key: "TEST-2"}, at, names))
+}
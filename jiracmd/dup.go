@@ -56,8 +56,8 @@ func CmdDupUsage(cmd *kingpin.CmdClause, opts *DupOptions) error {
 		}
 		return nil
 	}).String()
-	cmd.Arg("DUPLICATE", "duplicate issue to mark closed").Required().StringVar(&opts.InwardIssue.Key)
-	cmd.Arg("ISSUE", "duplicate issue to leave open").Required().StringVar(&opts.OutwardIssue.Key)
+	cmd.Arg("DUPLICATE", "duplicate issue to mark closed").HintAction(jiracli.IssueHint()).Required().StringVar(&opts.InwardIssue.Key)
+	jiracli.IssueArgUsage(cmd, "duplicate issue to leave open", &opts.OutwardIssue.Key)
 	return nil
 }
 
@@ -39,7 +39,8 @@ func CmdEditRegistry() *jiracli.CommandRegistryEntry {
 		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
 			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
 			if opts.QueryFields == "" {
-				opts.QueryFields = "assignee,created,priority,reporter,status,summary,updated,issuetype,comment,description,votes,created,customfield_10110,components"
+				// the same fields we get from a single issue
+				opts.QueryFields = "*all"
 			}
 			return CmdEdit(o, globals, &opts)
 		},
@@ -49,6 +50,7 @@ func CmdEditRegistry() *jiracli.CommandRegistryEntry {
 func CmdEditUsage(cmd *kingpin.CmdClause, opts *EditOptions, fig *figtree.FigTree) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.FileUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
 	cmd.Flag("named-query", "The name of a query in the `queries` configuration").Short('n').PreAction(func(ctx *kingpin.ParseContext) error {
@@ -66,14 +68,16 @@ func CmdEditUsage(cmd *kingpin.CmdClause, opts *EditOptions, fig *figtree.FigTre
 		return nil
 	}).String()
 	cmd.Flag("override", "Set issue property").Short('o').StringMapVar(&opts.Overrides)
-	cmd.Arg("ISSUE", "issue id to edit").StringVar(&opts.Issue)
+	jiracli.OptionalIssueArgUsage(cmd, "issue id to edit", &opts.Issue)
+	jiracli.SkipIssuePicker(cmd, func() bool { return opts.Query != "" })
 	return nil
 }
 
 // Edit will get issue data and send to "edit" template
 func CmdEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EditOptions) error {
+	cache := jiracli.CacheClient(o, globals)
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(cache, globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
@@ -90,7 +94,7 @@ func CmdEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EditOptions)
 		if err != nil {
 			return err
 		}
-		editMeta, err := jira.GetIssueEditMeta(o, globals.Endpoint.Value, opts.Issue)
+		editMeta, err := jira.GetIssueEditMeta(cache, globals.Endpoint.Value, opts.Issue)
 		if err != nil {
 			return err
 		}
@@ -101,15 +105,26 @@ func CmdEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EditOptions)
 			Meta:      editMeta,
 			Overrides: opts.Overrides,
 		}
-		err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, func() error {
+		submit := func() error {
+			if err := jiracli.ResolveIssueUpdate(&issueUpdate, editMeta.Fields, opts.Overrides); err != nil {
+				return err
+			}
+			if err := jiracli.CheckIssueUpdate(&issueUpdate, editMeta.Fields, "edit"); err != nil {
+				return err
+			}
 			if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 				err := fixGDPRUserFields(o, globals.Endpoint.Value, editMeta.Fields, issueUpdate.Fields)
 				if err != nil {
 					return err
 				}
 			}
-			return jira.EditIssue(o, globals.Endpoint.Value, opts.Issue, &issueUpdate)
-		})
+			return jira.EditIssue(cache, globals.Endpoint.Value, opts.Issue, &issueUpdate)
+		}
+		if opts.File.Value != "" {
+			err = jiracli.ReadYmlInputFile(&opts.CommonOptions, &input, &issueUpdate, submit)
+		} else {
+			err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, submit)
+		}
 		if err != nil {
 			return err
 		}
@@ -121,12 +136,17 @@ func CmdEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EditOptions)
 		}
 		return nil
 	}
+	queryFields, err := jiracli.FieldIDs(opts.QueryFields)
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	opts.QueryFields = queryFields
 	results, err := jira.Search(o, globals.Endpoint.Value, opts)
 	if err != nil {
 		return err
 	}
 	for i, issueData := range results.Issues {
-		editMeta, err := jira.GetIssueEditMeta(o, globals.Endpoint.Value, issueData.Key)
+		editMeta, err := jira.GetIssueEditMeta(cache, globals.Endpoint.Value, issueData.Key)
 		if err != nil {
 			return err
 		}
@@ -138,13 +158,19 @@ func CmdEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EditOptions)
 			Overrides: opts.Overrides,
 		}
 		err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, func() error {
+			if err := jiracli.ResolveIssueUpdate(&issueUpdate, editMeta.Fields, opts.Overrides); err != nil {
+				return err
+			}
+			if err := jiracli.CheckIssueUpdate(&issueUpdate, editMeta.Fields, "edit"); err != nil {
+				return err
+			}
 			if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 				err := fixGDPRUserFields(o, globals.Endpoint.Value, editMeta.Fields, issueUpdate.Fields)
 				if err != nil {
 					return err
 				}
 			}
-			return jira.EditIssue(o, globals.Endpoint.Value, issueData.Key, &issueUpdate)
+			return jira.EditIssue(cache, globals.Endpoint.Value, issueData.Key, &issueUpdate)
 		})
 		if err == jiracli.EditLoopAbort && len(results.Issues) > i+1 {
 			var answer bool
@@ -184,8 +210,14 @@ func fixUserField(ua jira.HttpClient, endpoint string, userField map[string]inte
 	if !ok {
 		queryName, ok = userField["emailAddress"].(string)
 		if !ok {
-			// no fields to search on, skip user lookup
-			return nil
+			// a plain user name, like from an override, is not accepted
+			// by GDPR servers so it is replaced by the account
+			queryName, ok = userField["name"].(string)
+			if !ok {
+				// no fields to search on, skip user lookup
+				return nil
+			}
+			delete(userField, "name")
 		}
 	}
 	users, err := jira.UserSearch(ua, endpoint, &jira.UserSearchOptions{
@@ -39,7 +39,7 @@ func CmdEditMetaUsage(cmd *kingpin.CmdClause, opts *EditMetaOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "edit metadata for issue id").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "edit metadata for issue id", &opts.Issue)
 	return nil
 }
 
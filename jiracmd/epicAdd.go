@@ -38,8 +38,8 @@ func CmdEpicAddRegistry() *jiracli.CommandRegistryEntry {
 }
 
 func CmdEpicAddUsage(cmd *kingpin.CmdClause, opts *EpicAddOptions) error {
-	cmd.Arg("EPIC", "Epic Key or ID to add issues to").Required().StringVar(&opts.Epic)
-	cmd.Arg("ISSUE", "Issues to add to epic").Required().StringsVar(&opts.Issues)
+	cmd.Arg("EPIC", "Epic Key or ID to add issues to").HintAction(jiracli.IssueHint()).Required().StringVar(&opts.Epic)
+	jiracli.IssuesArgUsage(cmd, "Issues to add to epic", &opts.Issues)
 	return nil
 }
 
@@ -13,6 +13,8 @@ func CmdEpicCreateRegistry() *jiracli.CommandRegistryEntry {
 		CommonOptions: jiracli.CommonOptions{
 			Template: figtree.NewStringOption("epic-create"),
 		},
+		// the create metadata for the Epic has the Epic Name field
+		IssueType: "Epic",
 		Overrides: map[string]string{},
 	}
 
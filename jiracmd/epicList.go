@@ -46,11 +46,18 @@ func CmdEpicListRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdEpicListUsage(cmd *kingpin.CmdClause, opts *EpicListOptions, fig *figtree.FigTree) error {
 	CmdListUsage(cmd, &opts.ListOptions, fig)
-	cmd.Arg("EPIC", "Epic Key or ID to list").Required().StringVar(&opts.Epic)
+	cmd.Arg("EPIC", "Epic Key or ID to list").HintAction(jiracli.IssueHint()).Required().StringVar(&opts.Epic)
 	return nil
 }
 
 func CmdEpicList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *EpicListOptions) error {
+	if err := resolveFilterQuery(o, globals.Endpoint.Value, &opts.ListOptions); err != nil {
+		return err
+	}
+	var err error
+	if opts.QueryFields, err = jiracli.FieldIDs(opts.QueryFields); err != nil {
+		return jiracli.CliError(err)
+	}
 	data, err := jira.EpicSearch(o, globals.Endpoint.Value, opts.Epic, opts)
 	if err != nil {
 		return err
@@ -36,7 +36,7 @@ func CmdEpicRemoveRegistry() *jiracli.CommandRegistryEntry {
 }
 
 func CmdEpicRemoveUsage(cmd *kingpin.CmdClause, opts *EpicRemoveOptions) error {
-	cmd.Arg("ISSUE", "Issues to remove from any epic").Required().StringsVar(&opts.Issues)
+	jiracli.IssuesArgUsage(cmd, "Issues to remove from any epic", &opts.Issues)
 	return nil
 }
 
@@ -0,0 +1,67 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type FilterCreateOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	jiradata.Filter       `yaml:",inline" json:",inline" figtree:",inline"`
+}
+
+func CmdFilterCreateRegistry() *jiracli.CommandRegistryEntry {
+	opts := FilterCreateOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("filter-create"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Create a saved filter",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdFilterCreateUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdFilterCreate(o, globals, &opts)
+		},
+	}
+}
+
+func CmdFilterCreateUsage(cmd *kingpin.CmdClause, opts *FilterCreateOptions) error {
+	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
+	cmd.Flag("description", "description of filter").Short('d').StringVar(&opts.Description)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the filter").Short('q').StringVar(&opts.JQL)
+	cmd.Flag("favourite", "mark the filter as a favourite").BoolVar(&opts.Favourite)
+	cmd.Arg("NAME", "name of filter").StringVar(&opts.Name)
+	return nil
+}
+
+// CmdFilterCreate sends the provided options to the "filter-create" template for editing, then
+// will parse the edited document as YAML and submit the document to jira.
+func CmdFilterCreate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *FilterCreateOptions) error {
+	var resp *jiradata.Filter
+	filter := &jiradata.Filter{}
+	err := jiracli.EditLoop(&opts.CommonOptions, &opts.Filter, filter, func() error {
+		var err error
+		resp, err = jira.CreateFilter(o, globals.Endpoint.Value, filter)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", resp.ID, resp.ViewURL)
+	}
+	return nil
+}
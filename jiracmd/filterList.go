@@ -0,0 +1,37 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdFilterListRegistry() *jiracli.CommandRegistryEntry {
+	opts := jiracli.CommonOptions{
+		Template: figtree.NewStringOption("filters"),
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints your favourite saved filters",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			jiracli.TemplateUsage(cmd, &opts)
+			jiracli.GJsonQueryUsage(cmd, &opts)
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdFilterList(o, globals, &opts)
+		},
+	}
+}
+
+// CmdFilterList will send the favourite filters to the "filters" template
+func CmdFilterList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *jiracli.CommonOptions) error {
+	data, err := jira.GetFavouriteFilters(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
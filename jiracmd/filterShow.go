@@ -0,0 +1,72 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type FilterShowOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Filter                string `yaml:"filter,omitempty" json:"filter,omitempty"`
+}
+
+func CmdFilterShowRegistry() *jiracli.CommandRegistryEntry {
+	opts := FilterShowOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("filter"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints saved filter details",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdFilterShowUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdFilterShow(o, globals, &opts)
+		},
+	}
+}
+
+func CmdFilterShowUsage(cmd *kingpin.CmdClause, opts *FilterShowOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("FILTER", "Name or id of saved filter").Required().StringVar(&opts.Filter)
+	return nil
+}
+
+// CmdFilterShow will send the filter to the "filter" template
+func CmdFilterShow(o *oreo.Client, globals *jiracli.GlobalOptions, opts *FilterShowOptions) error {
+	data, err := findFilter(o, globals.Endpoint.Value, opts.Filter)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
+
+// findFilter will return the filter by id, or if the argument is not numeric
+// it will search the favourite filters for a filter with that name.
+func findFilter(ua jira.HttpClient, endpoint string, nameOrID string) (*jiradata.Filter, error) {
+	if _, err := strconv.Atoi(nameOrID); err == nil {
+		return jira.GetFilter(ua, endpoint, nameOrID)
+	}
+	filters, err := jira.GetFavouriteFilters(ua, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range *filters {
+		if strings.EqualFold(filter.Name, nameOrID) {
+			return filter, nil
+		}
+	}
+	return nil, fmt.Errorf("No favourite filter named %q found, use the filter id or mark the filter as a favourite", nameOrID)
+}
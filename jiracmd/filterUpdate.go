@@ -0,0 +1,84 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type FilterUpdateOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Filter                string `yaml:"filter,omitempty" json:"filter,omitempty"`
+	Name                  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Description           string `yaml:"description,omitempty" json:"description,omitempty"`
+	Query                 string `yaml:"query,omitempty" json:"query,omitempty"`
+}
+
+func CmdFilterUpdateRegistry() *jiracli.CommandRegistryEntry {
+	opts := FilterUpdateOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("filter-update"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Update a saved filter",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdFilterUpdateUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdFilterUpdate(o, globals, &opts)
+		},
+	}
+}
+
+func CmdFilterUpdateUsage(cmd *kingpin.CmdClause, opts *FilterUpdateOptions) error {
+	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
+	cmd.Flag("name", "new name of filter").Short('n').StringVar(&opts.Name)
+	cmd.Flag("description", "description of filter").Short('d').StringVar(&opts.Description)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the filter").Short('q').StringVar(&opts.Query)
+	cmd.Arg("FILTER", "Name or id of saved filter").Required().StringVar(&opts.Filter)
+	return nil
+}
+
+// CmdFilterUpdate sends the existing filter to the "filter-update" template for editing, then
+// will parse the edited document as YAML and submit the document to jira.
+func CmdFilterUpdate(o *oreo.Client, globals *jiracli.GlobalOptions, opts *FilterUpdateOptions) error {
+	current, err := findFilter(o, globals.Endpoint.Value, opts.Filter)
+	if err != nil {
+		return err
+	}
+	if opts.Name != "" {
+		current.Name = opts.Name
+	}
+	if opts.Description != "" {
+		current.Description = opts.Description
+	}
+	if opts.Query != "" {
+		current.JQL = opts.Query
+	}
+
+	var resp *jiradata.Filter
+	filter := &jiradata.Filter{}
+	err = jiracli.EditLoop(&opts.CommonOptions, current, filter, func() error {
+		var err error
+		resp, err = jira.UpdateFilter(o, globals.Endpoint.Value, current.ID, filter)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", resp.ID, resp.ViewURL)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package jiracmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GitBranchOptions struct {
+	Project          string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue            string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	BranchTemplate   string `yaml:"branch-template,omitempty" json:"branch-template,omitempty"`
+	BranchTransition string `yaml:"branch-transition,omitempty" json:"branch-transition,omitempty"`
+}
+
+// gitSlugLength is the longest summary slug we will put in a branch name
+const gitSlugLength = 50
+
+func CmdGitBranchRegistry() *jiracli.CommandRegistryEntry {
+	opts := GitBranchOptions{
+		BranchTemplate:   "{{ .key }}-{{ .slug }}",
+		BranchTransition: "In Progress",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Create a git branch for the issue and start working on it",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGitBranchUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdGitBranch(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGitBranchUsage(cmd *kingpin.CmdClause, opts *GitBranchOptions) error {
+	cmd.Flag("branch-template", "Template for the branch name, the issue data and the summary .slug are available").StringVar(&opts.BranchTemplate)
+	cmd.Flag("transition", "Transition to run on the issue, use \"\" to leave the issue alone").StringVar(&opts.BranchTransition)
+	jiracli.IssueArgUsage(cmd, "issue to create a branch for", &opts.Issue)
+	return nil
+}
+
+// CmdGitBranch will create (or switch to) the branch named from the
+// branch-template and then transition the issue.
+func CmdGitBranch(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GitBranchOptions) error {
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, nil)
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+
+	data := map[string]interface{}{}
+	if err := jiracli.ConvertType(issue, &data); err != nil {
+		return err
+	}
+	summary, _ := issue.Fields["summary"].(string)
+	data["slug"] = gitSlug(summary, gitSlugLength)
+
+	tmpl, err := jiracli.TemplateProcessor().Parse(opts.BranchTemplate)
+	if err != nil {
+		return err
+	}
+	buf := bytes.NewBufferString("")
+	if err := tmpl.Execute(buf, data); err != nil {
+		return err
+	}
+	branch := strings.Trim(strings.TrimSpace(buf.String()), "-/")
+	if _, err := git("check-ref-format", "--branch", branch); err != nil {
+		return jiracli.CliError(fmt.Errorf("Invalid branch name %q from the branch-template", branch))
+	}
+
+	if _, err := git("rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		_, err = git("checkout", branch)
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+	} else if _, err := git("checkout", "-b", branch); err != nil {
+		return jiracli.CliError(err)
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK switched to branch %s\n", branch)
+	}
+
+	if opts.BranchTransition == "" {
+		return nil
+	}
+	if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+		if name, _ := status["name"].(string); strings.EqualFold(name, opts.BranchTransition) {
+			return nil
+		}
+	}
+	err = CmdTransition(o, globals, &TransitionOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template:    figtree.NewStringOption("transition"),
+			SkipEditing: figtree.NewBoolOption(true),
+		},
+		Overrides:  map[string]string{},
+		Transition: opts.BranchTransition,
+		Issue:      issue.Key,
+	})
+	if err != nil {
+		// the branch is already created, so just let the user know
+		log.Warningf("Unable to transition %s: %s", issue.Key, err)
+	}
+	return nil
+}
+
+// gitSlug will turn the summary into lower case words joined by dashes,
+// trimmed to whole words that fit in max characters.
+func gitSlug(summary string, max int) string {
+	words := strings.FieldsFunc(strings.ToLower(summary), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	slug := ""
+	for _, word := range words {
+		if slug == "" {
+			if len(word) > max {
+				word = word[:max]
+			}
+			slug = word
+			continue
+		}
+		if len(slug)+len(word)+1 > max {
+			break
+		}
+		slug += "-" + word
+	}
+	return slug
+}
@@ -0,0 +1,25 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitSlug(t *testing.T) {
+	for summary, expected := range map[string]string{
+		"Fix the login page":                 "fix-the-login-page",
+		"  Crash in `jira edit` -- again!  ": "crash-in-jira-edit-again",
+		"Upgrade to Go 1.12":                 "upgrade-to-go-1-12",
+		"":                                   "",
+		"!!!":                                "",
+	} {
+		assert.Equal(t, expected, gitSlug(summary, gitSlugLength), summary)
+	}
+
+	// only whole words that fit
+	assert.Equal(t, "fix-the", gitSlug("Fix the login page", 10))
+	assert.Equal(t, "fix-the-login", gitSlug("Fix the login page", 13))
+	// a single long word is cut
+	assert.Equal(t, "internation", gitSlug("Internationalization", 11))
+}
@@ -0,0 +1,122 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GitCommitMsgOptions struct {
+	File         string `yaml:"file,omitempty" json:"file,omitempty"`
+	RequireIssue bool   `yaml:"require-issue,omitempty" json:"require-issue,omitempty"`
+	AddIssue     bool   `yaml:"add-issue,omitempty" json:"add-issue,omitempty"`
+}
+
+// gitScissors is the line git uses to mark the end of the message when
+// committing with --verbose
+const gitScissors = "# ------------------------ >8 ------------------------"
+
+func CmdGitCommitMsgRegistry() *jiracli.CommandRegistryEntry {
+	opts := GitCommitMsgOptions{
+		RequireIssue: true,
+		AddIssue:     true,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Check the issues in a commit message, for use as a commit-msg hook",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGitCommitMsgUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGitCommitMsg(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGitCommitMsgUsage(cmd *kingpin.CmdClause, opts *GitCommitMsgOptions) error {
+	cmd.Flag("require-issue", "Reject commit messages without an issue key").BoolVar(&opts.RequireIssue)
+	cmd.Flag("add-issue", "Add the issue from the branch name when the message does not have one").BoolVar(&opts.AddIssue)
+	cmd.Arg("FILE", "The commit message file git passes to the commit-msg hook").Required().ExistingFileVar(&opts.File)
+	return nil
+}
+
+// CmdGitCommitMsg will make sure the issues mentioned in the commit message
+// exist.  The smart commit commands are left for the post-commit hook, the
+// commit can still be aborted after this hook runs.
+func CmdGitCommitMsg(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GitCommitMsgOptions) error {
+	content, err := ioutil.ReadFile(opts.File)
+	if err != nil {
+		return err
+	}
+	lines := gitMessageLines(string(content))
+
+	issues := []string{}
+	seen := map[string]bool{}
+	for _, line := range lines {
+		for _, key := range gitMessageIssue.FindAllString(line, -1) {
+			if !seen[key] {
+				seen[key] = true
+				issues = append(issues, key)
+			}
+		}
+	}
+
+	if len(issues) == 0 && opts.AddIssue {
+		if branch, err := gitCurrentBranch(); err == nil {
+			if key := gitIssueFromBranch(branch); key != "" {
+				if err := gitAddIssue(opts.File, string(content), key); err != nil {
+					return err
+				}
+				issues = append(issues, key)
+			}
+		}
+	}
+	if len(issues) == 0 {
+		if opts.RequireIssue {
+			return jiracli.CliError(fmt.Errorf("No issue key found in the commit message or branch name"))
+		}
+		return nil
+	}
+
+	for _, key := range issues {
+		if _, err := jira.GetIssue(o, globals.Endpoint.Value, key, &jira.IssueOptions{Fields: []string{"summary"}}); err != nil {
+			return jiracli.CliError(fmt.Errorf("Unable to find %s: %s", key, err))
+		}
+	}
+	return nil
+}
+
+// gitMessageLines returns the lines of the commit message without the git
+// comments and anything after the scissors line.
+func gitMessageLines(content string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if line == gitScissors {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// gitAddIssue will prefix the first line of the message with the issue key.
+func gitAddIssue(file, content, key string) error {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+			lines[i] = key + " " + line
+			return ioutil.WriteFile(file, []byte(strings.Join(lines, "\n")), 0644)
+		}
+	}
+	return nil
+}
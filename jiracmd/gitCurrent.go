@@ -0,0 +1,76 @@
+package jiracmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	// gitBranchIssue finds the issue key in a branch name, the key must be
+	// upper case like in the commit messages so branches like "release-2" are
+	// not mistaken for issues
+	gitBranchIssue = regexp.MustCompile(`(?:^|[^A-Za-z0-9])([A-Z][A-Z0-9_]+-[1-9][0-9]*)(?:$|[^0-9])`)
+	// gitMessageIssue finds the issue keys in a commit message, these must be
+	// upper case so things like "utf-8" are not mistaken for issues
+	gitMessageIssue = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+)
+
+func CmdGitCurrentRegistry() *jiracli.CommandRegistryEntry {
+	return &jiracli.CommandRegistryEntry{
+		"Print the issue for the current git branch",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGitCurrent()
+		},
+	}
+}
+
+// CmdGitCurrent will print the issue key found in the current branch name
+func CmdGitCurrent() error {
+	branch, err := gitCurrentBranch()
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	issue := gitIssueFromBranch(branch)
+	if issue == "" {
+		return jiracli.CliError(fmt.Errorf("No issue found in branch %q", branch))
+	}
+	fmt.Println(issue)
+	return nil
+}
+
+func gitIssueFromBranch(branch string) string {
+	if match := gitBranchIssue.FindStringSubmatch(branch); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func gitCurrentBranch() (string, error) {
+	return git("symbolic-ref", "--short", "HEAD")
+}
+
+// git will run the git command and return the trimmed stdout
+func git(args ...string) (string, error) {
+	log.Debugf("Running: git %s", strings.Join(args, " "))
+	stdout, stderr := bytes.NewBufferString(""), bytes.NewBufferString("")
+	cmd := exec.Command("git", args...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
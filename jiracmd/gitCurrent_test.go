@@ -0,0 +1,28 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitIssueFromBranch(t *testing.T) {
+	for branch, expected := range map[string]string{
+		"GOJIRA-12-fix-the-login-page":  "GOJIRA-12",
+		"GOJIRA-12":                     "GOJIRA-12",
+		"feature/OPS_2-123/login":       "OPS_2-123",
+		"alice/TEST-7_retry":            "TEST-7",
+		"fix-TEST-9":                    "TEST-9",
+		"release-2":                     "",
+		"release-2.1":                   "",
+		"hotfix/utf-8-names":            "",
+		"gojira-12-fix-the-login-page":  "",
+		"TEST-0":                        "",
+		"TEST-":                         "",
+		"master":                        "",
+		"xTEST-1":                       "",
+		"TEST-1234567-with-a-long-slug": "TEST-1234567",
+	} {
+		assert.Equal(t, expected, gitIssueFromBranch(branch), branch)
+	}
+}
@@ -0,0 +1,214 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// smartCommit is a #command found after the issue keys in a commit message
+type smartCommit struct {
+	Issues  []string
+	Command string
+	Args    string
+}
+
+var (
+	smartCommand = regexp.MustCompile(`(?:^|\s)#([A-Za-z][A-Za-z0-9_-]*)`)
+	smartTime    = regexp.MustCompile(`^\d+(\.\d+)?[wdhm]$`)
+)
+
+// gitSmartCommitsFile is the file in the git directory listing the commits
+// the smart commit commands have been run for
+const gitSmartCommitsFile = "jira-smart-commits"
+
+// gitNewCommitActions are the reflog actions for commits that were just
+// written by the user, amended, rebased, cherry-picked and reverted commits
+// reuse a message that has already been processed.
+var gitNewCommitActions = map[string]bool{
+	"commit":           true,
+	"commit (initial)": true,
+	"commit (merge)":   true,
+}
+
+func CmdGitPostCommitRegistry() *jiracli.CommandRegistryEntry {
+	return &jiracli.CommandRegistryEntry{
+		"Run the smart commit commands in the last commit message, for use as a post-commit hook",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGitPostCommit(o, globals)
+		},
+	}
+}
+
+// CmdGitPostCommit will apply the smart commit commands in the message of
+// the commit that was just made.  Each commit is only processed once, and
+// commits that reuse an earlier message (amend, rebase, cherry-pick) are
+// skipped so the commands are not repeated.
+func CmdGitPostCommit(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+	sha, err := git("rev-parse", "HEAD")
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	// without a reflog we cannot tell how the commit was made, so rely on
+	// the processed commits alone
+	if action, err := git("reflog", "-1", "--format=%gs", "HEAD"); err == nil && action != "" {
+		if i := strings.Index(action, ":"); i >= 0 {
+			action = action[:i]
+		}
+		if !gitNewCommitActions[action] {
+			log.Debugf("Skipping the smart commits for %s from %q", sha, action)
+			return nil
+		}
+	}
+
+	file, err := git("rev-parse", "--git-path", gitSmartCommitsFile)
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	processed, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(processed), "\n") {
+		if line == sha {
+			log.Debugf("Smart commits for %s have already been run", sha)
+			return nil
+		}
+	}
+	// record the commit first, a command that fails is reported rather than
+	// retried on the next commit
+	fh, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(fh, sha)
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	message, err := git("log", "-1", "--format=%B", sha)
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	lines := strings.Split(message, "\n")
+	issues := []string{}
+	seen := map[string]bool{}
+	for _, line := range lines {
+		for _, key := range gitMessageIssue.FindAllString(line, -1) {
+			if !seen[key] {
+				seen[key] = true
+				issues = append(issues, key)
+			}
+		}
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	// the commit has already been made, so just report the failures
+	for _, command := range parseSmartCommits(lines, issues) {
+		for _, key := range command.Issues {
+			if err := runSmartCommit(o, globals, key, command); err != nil {
+				log.Errorf("#%s for %s failed: %s", command.Command, key, err)
+			}
+			jiracli.InvalidateIssueCache(globals, key)
+		}
+	}
+	return nil
+}
+
+// parseSmartCommits finds the #commands in the message.  The commands apply
+// to the issue keys earlier on the same line, or to all the issues in the
+// message when the line does not have any.
+func parseSmartCommits(lines []string, issues []string) []*smartCommit {
+	commands := []*smartCommit{}
+	for _, line := range lines {
+		matches := smartCommand.FindAllStringSubmatchIndex(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		keys := gitMessageIssue.FindAllString(line[:matches[0][0]], -1)
+		if len(keys) == 0 {
+			keys = issues
+		}
+		for i, match := range matches {
+			end := len(line)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			commands = append(commands, &smartCommit{
+				Issues:  keys,
+				Command: strings.ToLower(line[match[2]:match[3]]),
+				Args:    strings.TrimSpace(line[match[1]:end]),
+			})
+		}
+	}
+	return commands
+}
+
+func runSmartCommit(o *oreo.Client, globals *jiracli.GlobalOptions, key string, command *smartCommit) error {
+	switch command.Command {
+	case "comment":
+		if command.Args == "" {
+			return fmt.Errorf("#comment needs some text")
+		}
+		if _, err := jira.IssueAddComment(o, globals.Endpoint.Value, key, &jiradata.Comment{Body: command.Args}); err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK commented on %s\n", key)
+		}
+	case "time":
+		spent := []string{}
+		words := strings.Fields(command.Args)
+		for len(words) > 0 && smartTime.MatchString(words[0]) {
+			spent = append(spent, words[0])
+			words = words[1:]
+		}
+		if len(spent) == 0 {
+			return fmt.Errorf("#time needs a duration like 1h 30m")
+		}
+		worklog := &jiradata.Worklog{
+			TimeSpent: strings.Join(spent, " "),
+			Comment:   strings.Join(words, " "),
+		}
+		if _, err := jira.AddIssueWorklog(o, globals.Endpoint.Value, key, worklog); err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK logged %s on %s\n", worklog.TimeSpent, key)
+		}
+	default:
+		// any other command is a transition, with dashes for the spaces
+		// in the transition name, ie #start-progress
+		opts := &TransitionOptions{
+			CommonOptions: jiracli.CommonOptions{
+				Template:    figtree.NewStringOption("transition"),
+				SkipEditing: figtree.NewBoolOption(true),
+			},
+			Overrides:  map[string]string{},
+			Transition: strings.Replace(command.Command, "-", " ", -1),
+			Issue:      key,
+		}
+		if command.Args != "" {
+			opts.Overrides["comment"] = command.Args
+		}
+		return CmdTransition(o, globals, opts)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSmartCommits(t *testing.T) {
+	issues := []string{"TEST-1", "TEST-2"}
+	for name, test := range map[string]struct {
+		lines    []string
+		expected []*smartCommit
+	}{
+		"no commands": {
+			[]string{"TEST-1 fix the login page", "", "see #123 in github"},
+			[]*smartCommit{},
+		},
+		"keys on the line": {
+			[]string{"TEST-1 TEST-2 #comment fixed the login page"},
+			[]*smartCommit{
+				{Issues: []string{"TEST-1", "TEST-2"}, Command: "comment", Args: "fixed the login page"},
+			},
+		},
+		"all issues without keys on the line": {
+			[]string{"TEST-1 fix the login page", "", "#time 1h 30m debugging"},
+			[]*smartCommit{
+				{Issues: issues, Command: "time", Args: "1h 30m debugging"},
+			},
+		},
+		"several commands": {
+			[]string{"TEST-2 #Start-Progress #comment on it #time 2h"},
+			[]*smartCommit{
+				{Issues: []string{"TEST-2"}, Command: "start-progress", Args: ""},
+				{Issues: []string{"TEST-2"}, Command: "comment", Args: "on it"},
+				{Issues: []string{"TEST-2"}, Command: "time", Args: "2h"},
+			},
+		},
+		"only keys before the command": {
+			[]string{"TEST-1 #comment see TEST-2"},
+			[]*smartCommit{
+				{Issues: []string{"TEST-1"}, Command: "comment", Args: "see TEST-2"},
+			},
+		},
+		"hash inside a word": {
+			[]string{"TEST-1 use the C# client and issue#4"},
+			[]*smartCommit{},
+		},
+	} {
+		assert.Equal(t, test.expected, parseSmartCommits(test.lines, issues), name)
+	}
+}
@@ -0,0 +1,393 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GraphOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Query                 string `yaml:"query,omitempty" json:"query,omitempty"`
+	LinkType              string `yaml:"link-type,omitempty" json:"link-type,omitempty"`
+	Format                string `yaml:"format,omitempty" json:"format,omitempty"`
+}
+
+// graphData is sent to the "graph" templates.  An edge goes from the issue
+// with the outward description of the link type (ie "blocks") to the issue
+// with the inward description (ie "is blocked by").
+type graphData struct {
+	Query    string                  `json:"query" yaml:"query"`
+	LinkType *jiradata.IssueLinkType `json:"linkType" yaml:"linkType"`
+	Nodes    []*graphNode            `json:"nodes" yaml:"nodes"`
+	Edges    []*graphEdge            `json:"edges" yaml:"edges"`
+	// Cycles has the keys of the issues in each group of issues that
+	// depend on each other
+	Cycles [][]string `json:"cycles" yaml:"cycles"`
+	// CriticalPath is the chain of unresolved issues with the largest total
+	// original estimate
+	CriticalPath    []*graphNode `json:"criticalPath" yaml:"criticalPath"`
+	Estimate        string       `json:"estimate" yaml:"estimate"`
+	EstimateSeconds int          `json:"estimateSeconds" yaml:"estimateSeconds"`
+	// Unestimated are the issues on the critical path without an estimate
+	Unestimated []string `json:"unestimated" yaml:"unestimated"`
+}
+
+type graphNode struct {
+	Key             string           `json:"key" yaml:"key"`
+	Summary         string           `json:"summary" yaml:"summary"`
+	Status          *jiradata.Status `json:"status" yaml:"status"`
+	Assignee        string           `json:"assignee" yaml:"assignee"`
+	URL             string           `json:"url" yaml:"url"`
+	Estimate        string           `json:"estimate" yaml:"estimate"`
+	EstimateSeconds int              `json:"estimateSeconds" yaml:"estimateSeconds"`
+	Resolved        bool             `json:"resolved" yaml:"resolved"`
+	// External is set for the issues linked to the issues from the query
+	// that the query did not find
+	External bool `json:"external" yaml:"external"`
+	Cycle    bool `json:"cycle" yaml:"cycle"`
+	Critical bool `json:"critical" yaml:"critical"`
+	// BlockedBy are the unresolved issues on the inward side of the links
+	BlockedBy []string `json:"blockedBy" yaml:"blockedBy"`
+}
+
+type graphEdge struct {
+	From     string `json:"from" yaml:"from"`
+	To       string `json:"to" yaml:"to"`
+	Cycle    bool   `json:"cycle" yaml:"cycle"`
+	Critical bool   `json:"critical" yaml:"critical"`
+}
+
+func CmdGraphRegistry() *jiracli.CommandRegistryEntry {
+	opts := GraphOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("graph"),
+		},
+		LinkType: "Blocks",
+		Format:   "text",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the dependency graph of the issues with cycles and the critical path",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGraphUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGraph(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGraphUsage(cmd *kingpin.CmdClause, opts *GraphOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "project to graph").Short('p').StringVar(&opts.Project)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to graph").Short('q').StringVar(&opts.Query)
+	cmd.Flag("link-type", "Name of the link type for the dependencies").StringVar(&opts.LinkType)
+	cmd.Flag("format", "Output format: text, dot, mermaid or json").EnumVar(&opts.Format, "text", "dot", "mermaid", "json")
+	return nil
+}
+
+// CmdGraph will build the graph of the links between the issues found by the
+// query, then find the cycles and the critical path and send it to the
+// "graph" template, or the "graph-dot", "graph-mermaid" or "json" template.
+func CmdGraph(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GraphOptions) error {
+	// every unresolved issue would be far too many to graph
+	if opts.Query == "" && opts.Project == "" {
+		return jiracli.CliError(fmt.Errorf("One of --query or --project is required"))
+	}
+	if opts.Format != "text" && opts.Template.Value == "graph" {
+		opts.Template.Value = "graph-" + opts.Format
+		if opts.Format == "json" {
+			opts.Template.Value = "json"
+		}
+	}
+
+	linkTypes, err := jira.GetIssueLinkTypes(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	data := &graphData{
+		Query:       reportQuery(opts.Query, opts.Project, "resolution = unresolved ORDER BY key"),
+		Nodes:       []*graphNode{},
+		Edges:       []*graphEdge{},
+		Cycles:      [][]string{},
+		Unestimated: []string{},
+	}
+	names := []string{}
+	for _, linkType := range *linkTypes {
+		names = append(names, linkType.Name)
+		for _, name := range []string{linkType.Name, linkType.Outward, linkType.Inward} {
+			if strings.EqualFold(name, opts.LinkType) {
+				data.LinkType = linkType
+			}
+		}
+	}
+	if data.LinkType == nil {
+		return jiracli.CliError(fmt.Errorf("Unknown link type %q, available: %s", opts.LinkType, strings.Join(names, ", ")))
+	}
+
+	fields := "status,resolution,assignee,issuelinks,timeoriginalestimate"
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:       data.Query,
+		QueryFields: fields,
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+
+	nodes := map[string]*graphNode{}
+	for _, issue := range results.Issues {
+		nodes[issue.Key] = graphNewNode(globals, issue)
+	}
+	edges := map[[2]string]bool{}
+	for _, issue := range results.Issues {
+		links, _ := issue.Fields["issuelinks"].([]interface{})
+		for _, value := range links {
+			link, _ := value.(map[string]interface{})
+			linkType, _ := link["type"].(map[string]interface{})
+			if linkType == nil || fmt.Sprint(linkType["name"]) != data.LinkType.Name {
+				continue
+			}
+			if outward, ok := link["outwardIssue"].(map[string]interface{}); ok {
+				edges[[2]string{issue.Key, fmt.Sprint(outward["key"])}] = true
+			}
+			if inward, ok := link["inwardIssue"].(map[string]interface{}); ok {
+				edges[[2]string{fmt.Sprint(inward["key"]), issue.Key}] = true
+			}
+		}
+	}
+
+	// look up the linked issues the query did not find, for their estimates
+	external := []string{}
+	for edge := range edges {
+		for _, key := range edge {
+			if _, ok := nodes[key]; !ok {
+				nodes[key] = nil
+				external = append(external, key)
+			}
+		}
+	}
+	sort.Strings(external)
+	for start := 0; start < len(external); start += 50 {
+		found, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query:       fmt.Sprintf("key in (%s)", strings.Join(external[start:planMin(start+50, len(external))], ",")),
+			QueryFields: fields,
+		}, jira.WithAutoPagination())
+		if err != nil {
+			return err
+		}
+		for _, issue := range found.Issues {
+			nodes[issue.Key] = graphNewNode(globals, issue)
+			nodes[issue.Key].External = true
+		}
+	}
+
+	for _, key := range graphSortedKeys(nodes) {
+		if nodes[key] == nil {
+			// the issue is gone or we are not allowed to see it
+			delete(nodes, key)
+			continue
+		}
+		data.Nodes = append(data.Nodes, nodes[key])
+	}
+	for edge := range edges {
+		if nodes[edge[0]] != nil && nodes[edge[1]] != nil {
+			data.Edges = append(data.Edges, &graphEdge{From: edge[0], To: edge[1]})
+		}
+	}
+	sort.Slice(data.Edges, func(i, j int) bool {
+		if data.Edges[i].From != data.Edges[j].From {
+			return data.Edges[i].From < data.Edges[j].From
+		}
+		return data.Edges[i].To < data.Edges[j].To
+	})
+	for _, edge := range data.Edges {
+		if from := nodes[edge.From]; !from.Resolved {
+			nodes[edge.To].BlockedBy = append(nodes[edge.To].BlockedBy, from.Key)
+		}
+	}
+
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	return opts.PrintTemplate(data)
+}
+
+func graphNewNode(globals *jiracli.GlobalOptions, issue *jiradata.Issue) *graphNode {
+	node := &graphNode{
+		Key:       issue.Key,
+		URL:       jira.URLJoin(globals.Endpoint.Value, "browse", issue.Key),
+		Assignee:  fieldUserName(issue.Fields["assignee"]),
+		Status:    &jiradata.Status{},
+		Resolved:  issue.Fields["resolution"] != nil || reportStatusCategory(issue) == "done",
+		BlockedBy: []string{},
+	}
+	node.Summary, _ = issue.Fields["summary"].(string)
+	jiracli.ConvertType(issue.Fields["status"], node.Status)
+	if estimate, ok := issue.Fields["timeoriginalestimate"].(float64); ok {
+		node.EstimateSeconds = int(estimate)
+		node.Estimate = graphDuration(node.EstimateSeconds)
+	}
+	return node
+}
+
+// graphCycles finds the strongly connected components of the graph with
+// Tarjan's algorithm, each component with more than one issue (or an issue
+// linked to itself) is a cycle.
+func graphCycles(data *graphData, nodes map[string]*graphNode) {
+	next := map[string][]string{}
+	for _, edge := range data.Edges {
+		next[edge.From] = append(next[edge.From], edge.To)
+	}
+
+	index, low, onStack := map[string]int{}, map[string]int{}, map[string]bool{}
+	stack := []string{}
+	component := map[string]int{}
+	components := 0
+	var connect func(key string)
+	connect = func(key string) {
+		index[key] = len(index)
+		low[key] = index[key]
+		stack = append(stack, key)
+		onStack[key] = true
+		for _, to := range next[key] {
+			if _, ok := index[to]; !ok {
+				connect(to)
+				if low[to] < low[key] {
+					low[key] = low[to]
+				}
+			} else if onStack[to] && index[to] < low[key] {
+				low[key] = index[to]
+			}
+		}
+		if low[key] != index[key] {
+			return
+		}
+		keys := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component[top] = components
+			keys = append(keys, top)
+			if top == key {
+				break
+			}
+		}
+		components++
+		if len(keys) > 1 {
+			sort.Strings(keys)
+			data.Cycles = append(data.Cycles, keys)
+		}
+	}
+	for _, node := range data.Nodes {
+		if _, ok := index[node.Key]; !ok {
+			connect(node.Key)
+		}
+	}
+
+	for _, edge := range data.Edges {
+		if component[edge.From] == component[edge.To] {
+			edge.Cycle = true
+			nodes[edge.From].Cycle = true
+			nodes[edge.To].Cycle = true
+			if edge.From == edge.To {
+				data.Cycles = append(data.Cycles, []string{edge.From})
+			}
+		}
+	}
+	sort.Slice(data.Cycles, func(i, j int) bool {
+		return data.Cycles[i][0] < data.Cycles[j][0]
+	})
+}
+
+// graphCriticalPath finds the chain of unresolved issues with the largest
+// total original estimate, or the most issues when the estimates are the
+// same.  The links in cycles are left out so the rest of the graph can
+// still be used.
+func graphCriticalPath(data *graphData, nodes map[string]*graphNode) {
+	next := map[string][]string{}
+	for _, edge := range data.Edges {
+		if !edge.Cycle && !nodes[edge.From].Resolved && !nodes[edge.To].Resolved {
+			next[edge.From] = append(next[edge.From], edge.To)
+		}
+	}
+
+	type chain struct {
+		seconds int
+		length  int
+		next    string
+	}
+	longest := map[string]*chain{}
+	var walk func(key string) *chain
+	walk = func(key string) *chain {
+		if best, ok := longest[key]; ok {
+			return best
+		}
+		best := &chain{seconds: nodes[key].EstimateSeconds, length: 1}
+		for _, to := range next[key] {
+			rest := walk(to)
+			if seconds, length := nodes[key].EstimateSeconds+rest.seconds, rest.length+1; seconds > best.seconds || seconds == best.seconds && length > best.length {
+				best = &chain{seconds: seconds, length: length, next: to}
+			}
+		}
+		longest[key] = best
+		return best
+	}
+
+	start := ""
+	for _, node := range data.Nodes {
+		if len(next[node.Key]) == 0 {
+			continue
+		}
+		best, current := walk(node.Key), longest[start]
+		if current == nil || best.seconds > current.seconds || best.seconds == current.seconds && best.length > current.length {
+			start = node.Key
+		}
+	}
+
+	data.CriticalPath = []*graphNode{}
+	for key := start; key != ""; key = longest[key].next {
+		node := nodes[key]
+		node.Critical = true
+		data.CriticalPath = append(data.CriticalPath, node)
+		data.EstimateSeconds += node.EstimateSeconds
+		if node.Estimate == "" {
+			data.Unestimated = append(data.Unestimated, key)
+		}
+	}
+	data.Estimate = graphDuration(data.EstimateSeconds)
+	for _, edge := range data.Edges {
+		edge.Critical = nodes[edge.From].Critical && nodes[edge.To].Critical && longest[edge.From].next == edge.To
+	}
+}
+
+// graphDuration formats the estimate in hours, like "12h30m", since the
+// length of a working day depends on the Jira settings.
+func graphDuration(seconds int) string {
+	duration := time.Duration(seconds) * time.Second
+	hours, minutes := int(duration.Hours()), int(duration.Minutes())%60
+	if minutes == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+func graphSortedKeys(nodes map[string]*graphNode) []string {
+	keys := []string{}
+	for key := range nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
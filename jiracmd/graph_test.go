@@ -0,0 +1,152 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// graphTestData builds the graph with the estimates in hours, a negative
+// estimate marks the issue resolved.
+func graphTestData(hours map[string]int, edges ...[2]string) (*graphData, map[string]*graphNode) {
+	data := &graphData{Nodes: []*graphNode{}, Edges: []*graphEdge{}, Cycles: [][]string{}, Unestimated: []string{}}
+	nodes := map[string]*graphNode{}
+	for key, estimate := range hours {
+		node := &graphNode{Key: key, Resolved: estimate < 0}
+		if estimate > 0 {
+			node.EstimateSeconds = estimate * 3600
+			node.Estimate = graphDuration(node.EstimateSeconds)
+		}
+		nodes[key] = node
+	}
+	for _, key := range graphSortedKeys(nodes) {
+		data.Nodes = append(data.Nodes, nodes[key])
+	}
+	for _, edge := range edges {
+		data.Edges = append(data.Edges, &graphEdge{From: edge[0], To: edge[1]})
+	}
+	return data, nodes
+}
+
+func graphPath(data *graphData) []string {
+	keys := []string{}
+	for _, node := range data.CriticalPath {
+		keys = append(keys, node.Key)
+	}
+	return keys
+}
+
+func TestGraphDAG(t *testing.T) {
+	data, nodes := graphTestData(
+		map[string]int{"A": 1, "B": 2, "C": 3, "D": 1, "E": 0},
+		[2]string{"A", "B"}, [2]string{"A", "C"}, [2]string{"B", "D"}, [2]string{"C", "D"}, [2]string{"D", "E"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+
+	assert.Equal(t, [][]string{}, data.Cycles)
+	for _, edge := range data.Edges {
+		assert.False(t, edge.Cycle, edge.From+"->"+edge.To)
+	}
+	assert.Equal(t, []string{"A", "C", "D", "E"}, graphPath(data))
+	assert.Equal(t, "5h", data.Estimate)
+	assert.Equal(t, 5*3600, data.EstimateSeconds)
+	assert.Equal(t, []string{"E"}, data.Unestimated)
+	critical := []string{}
+	for _, edge := range data.Edges {
+		if edge.Critical {
+			critical = append(critical, edge.From+"->"+edge.To)
+		}
+	}
+	assert.Equal(t, []string{"A->C", "C->D", "D->E"}, critical)
+	assert.False(t, nodes["B"].Critical)
+}
+
+func TestGraphTwoCycle(t *testing.T) {
+	data, nodes := graphTestData(
+		map[string]int{"A": 1, "B": 1, "C": 1},
+		[2]string{"A", "B"}, [2]string{"B", "A"}, [2]string{"B", "C"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+
+	assert.Equal(t, [][]string{{"A", "B"}}, data.Cycles)
+	assert.True(t, nodes["A"].Cycle)
+	assert.True(t, nodes["B"].Cycle)
+	assert.False(t, nodes["C"].Cycle)
+	assert.True(t, data.Edges[0].Cycle)
+	assert.True(t, data.Edges[1].Cycle)
+	assert.False(t, data.Edges[2].Cycle)
+	// the links in the cycle are left out of the critical path
+	assert.Equal(t, []string{"B", "C"}, graphPath(data))
+}
+
+func TestGraphSelfLink(t *testing.T) {
+	data, nodes := graphTestData(
+		map[string]int{"A": 2, "B": 1},
+		[2]string{"A", "A"}, [2]string{"A", "B"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+
+	assert.Equal(t, [][]string{{"A"}}, data.Cycles)
+	assert.True(t, nodes["A"].Cycle)
+	assert.False(t, nodes["B"].Cycle)
+	assert.Equal(t, []string{"A", "B"}, graphPath(data))
+	assert.Equal(t, "3h", data.Estimate)
+}
+
+func TestGraphCriticalPathTies(t *testing.T) {
+	// without estimates the longest chain wins
+	data, nodes := graphTestData(
+		map[string]int{"A": 0, "B": 0, "C": 0, "D": 0, "E": 0},
+		[2]string{"A", "B"}, [2]string{"C", "D"}, [2]string{"D", "E"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	assert.Equal(t, []string{"C", "D", "E"}, graphPath(data))
+	assert.Equal(t, "0h", data.Estimate)
+	assert.Equal(t, []string{"C", "D", "E"}, data.Unestimated)
+
+	// the estimate wins over the length
+	data, nodes = graphTestData(
+		map[string]int{"A": 5, "B": 1, "C": 1, "D": 1, "E": 1},
+		[2]string{"A", "B"}, [2]string{"C", "D"}, [2]string{"D", "E"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	assert.Equal(t, []string{"A", "B"}, graphPath(data))
+
+	// the same estimate and length keeps the first issue by key
+	data, nodes = graphTestData(
+		map[string]int{"A": 1, "B": 1, "C": 1, "D": 1},
+		[2]string{"C", "D"}, [2]string{"A", "B"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	assert.Equal(t, []string{"A", "B"}, graphPath(data))
+}
+
+func TestGraphCriticalPathResolved(t *testing.T) {
+	data, nodes := graphTestData(
+		map[string]int{"A": -1, "B": 1, "C": 2},
+		[2]string{"A", "B"}, [2]string{"B", "C"},
+	)
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	assert.Equal(t, []string{"B", "C"}, graphPath(data))
+
+	// nothing left to do
+	data, nodes = graphTestData(map[string]int{"A": -1, "B": -1}, [2]string{"A", "B"})
+	graphCycles(data, nodes)
+	graphCriticalPath(data, nodes)
+	assert.Equal(t, []string{}, graphPath(data))
+	assert.Equal(t, "0h", data.Estimate)
+}
+
+func TestGraphDuration(t *testing.T) {
+	assert.Equal(t, "0h", graphDuration(0))
+	assert.Equal(t, "2h", graphDuration(7200))
+	assert.Equal(t, "12h30m", graphDuration(45000))
+	assert.Equal(t, "0h45m", graphDuration(2700))
+}
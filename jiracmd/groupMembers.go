@@ -0,0 +1,49 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GroupMembersOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Group                 string `yaml:"group,omitempty" json:"group,omitempty"`
+}
+
+func CmdGroupMembersRegistry() *jiracli.CommandRegistryEntry {
+	opts := GroupMembersOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("group-members"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints members of a group",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGroupMembersUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGroupMembers(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGroupMembersUsage(cmd *kingpin.CmdClause, opts *GroupMembersOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("NAME", "name of group").Required().StringVar(&opts.Group)
+	return nil
+}
+
+// CmdGroupMembers will send the group members to the "group-members" template
+func CmdGroupMembers(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GroupMembersOptions) error {
+	data, err := jira.GetGroupMembers(o, globals.Endpoint.Value, opts.Group)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -51,9 +51,9 @@ func CmdIssueLinkUsage(cmd *kingpin.CmdClause, opts *IssueLinkOptions) error {
 		}
 		return nil
 	}).String()
-	cmd.Arg("OUTWARDISSUE", "outward issue").Required().StringVar(&opts.OutwardIssue.Key)
+	cmd.Arg("OUTWARDISSUE", "outward issue").HintAction(jiracli.IssueHint()).Required().StringVar(&opts.OutwardIssue.Key)
 	cmd.Arg("ISSUELINKTYPE", "issue link type").Required().StringVar(&opts.Type.Name)
-	cmd.Arg("INWARDISSUE", "inward issue").Required().StringVar(&opts.InwardIssue.Key)
+	cmd.Arg("INWARDISSUE", "inward issue").HintAction(jiracli.IssueHint()).Required().StringVar(&opts.InwardIssue.Key)
 	return nil
 }
 
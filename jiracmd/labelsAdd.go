@@ -36,8 +36,8 @@ func CmdLabelsAddRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdLabelsAddUsage(cmd *kingpin.CmdClause, opts *LabelsAddOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue id to modify labels").Required().StringVar(&opts.Issue)
-	cmd.Arg("LABEL", "label to add to issue").Required().StringsVar(&opts.Labels)
+	jiracli.IssueArgUsage(cmd, "issue id to modify labels", &opts.Issue)
+	jiracli.ArgAfterIssueUsage(cmd, "LABEL", "label to add to issue", &opts.Labels).HintAction(labelHint(nil))
 	return nil
 }
 
@@ -36,8 +36,8 @@ func CmdLabelsRemoveRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdLabelsRemoveUsage(cmd *kingpin.CmdClause, opts *LabelsRemoveOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue id to modify labels").Required().StringVar(&opts.Issue)
-	cmd.Arg("LABEL", "label to remove from issue").Required().StringsVar(&opts.Labels)
+	jiracli.IssueArgUsage(cmd, "issue id to modify labels", &opts.Issue)
+	jiracli.ArgAfterIssueUsage(cmd, "LABEL", "label to remove from issue", &opts.Labels).HintAction(labelHint(&opts.Issue))
 	return nil
 }
 
@@ -36,8 +36,8 @@ func CmdLabelsSetRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdLabelsSetUsage(cmd *kingpin.CmdClause, opts *LabelsSetOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue id to modify labels").Required().StringVar(&opts.Issue)
-	cmd.Arg("LABEL", "label to set on issue").Required().StringsVar(&opts.Labels)
+	jiracli.IssueArgUsage(cmd, "issue id to modify labels", &opts.Issue)
+	jiracli.ArgAfterIssueUsage(cmd, "LABEL", "label to set on issue", &opts.Labels).HintAction(labelHint(nil))
 	return nil
 }
 
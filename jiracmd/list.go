@@ -14,6 +14,7 @@ type ListOptions struct {
 	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
 	jira.SearchOptions    `yaml:",inline" json:",inline" figtree:",inline"`
 	Queries               map[string]string `yaml:"queries,omitempty" json:"queries,omitempty"`
+	Filter                string            `yaml:"filter,omitempty" json:"filter,omitempty"`
 }
 
 func CmdListRegistry() *jiracli.CommandRegistryEntry {
@@ -45,11 +46,12 @@ func CmdListUsage(cmd *kingpin.CmdClause, opts *ListOptions, fig *figtree.FigTre
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("assignee", "User assigned the issue").Short('a').StringVar(&opts.Assignee)
-	cmd.Flag("component", "Component to search for").Short('c').StringVar(&opts.Component)
-	cmd.Flag("issuetype", "Issue type to search for").Short('i').StringVar(&opts.IssueType)
+	cmd.Flag("component", "Component to search for").Short('c').HintAction(componentHint(&opts.Project)).StringVar(&opts.Component)
+	cmd.Flag("filter", "Name or id of a saved filter to use as the query").StringVar(&opts.Filter)
+	cmd.Flag("issuetype", "Issue type to search for").Short('i').HintAction(issueTypeHint(&opts.Project)).StringVar(&opts.IssueType)
 	cmd.Flag("limit", "Maximum number of results to return in search").Short('l').IntVar(&opts.MaxResults)
 	cmd.Flag("project", "Project to search for").Short('p').StringVar(&opts.Project)
-	cmd.Flag("named-query", "The name of a query in the `queries` configuration").Short('n').PreAction(func(ctx *kingpin.ParseContext) error {
+	cmd.Flag("named-query", "The name of a query in the `queries` configuration").Short('n').HintAction(namedQueryHint()).PreAction(func(ctx *kingpin.ParseContext) error {
 		name := jiracli.FlagValue(ctx, "named-query")
 		if query, ok := opts.Queries[name]; ok && query != "" {
 			var err error
@@ -69,9 +71,33 @@ func CmdListUsage(cmd *kingpin.CmdClause, opts *ListOptions, fig *figtree.FigTre
 
 // List will query jira and send data to "list" template
 func CmdList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ListOptions) error {
+	if err := resolveFilterQuery(o, globals.Endpoint.Value, opts); err != nil {
+		return err
+	}
+	var err error
+	if opts.QueryFields, err = jiracli.FieldIDs(opts.QueryFields); err != nil {
+		return jiracli.CliError(err)
+	}
 	data, err := jira.Search(o, globals.Endpoint.Value, opts, jira.WithAutoPagination())
 	if err != nil {
 		return err
 	}
 	return opts.PrintTemplate(data)
 }
+
+// resolveFilterQuery will use the JQL from the saved filter as the query
+// when --filter is used, it is an error to also give a query.
+func resolveFilterQuery(o *oreo.Client, endpoint string, opts *ListOptions) error {
+	if opts.Filter == "" {
+		return nil
+	}
+	if opts.Query != "" {
+		return jiracli.CliError(fmt.Errorf("--filter cannot be used with --query or --named-query"))
+	}
+	filter, err := findFilter(o, endpoint, opts.Filter)
+	if err != nil {
+		return err
+	}
+	opts.Query = filter.JQL
+	return nil
+}
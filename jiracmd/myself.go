@@ -0,0 +1,37 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdMyselfRegistry() *jiracli.CommandRegistryEntry {
+	opts := jiracli.CommonOptions{
+		Template: figtree.NewStringOption("myself"),
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints details of the currently authenticated user",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			jiracli.TemplateUsage(cmd, &opts)
+			jiracli.GJsonQueryUsage(cmd, &opts)
+			return nil
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdMyself(o, globals, &opts)
+		},
+	}
+}
+
+// CmdMyself will send the current user details to the "myself" template
+func CmdMyself(o *oreo.Client, globals *jiracli.GlobalOptions, opts *jiracli.CommonOptions) error {
+	data, err := jira.Myself(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -0,0 +1,475 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PlanOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Directory             string `yaml:"directory,omitempty" json:"directory,omitempty"`
+	IDLabelPrefix         string `yaml:"id-label-prefix,omitempty" json:"id-label-prefix,omitempty"`
+}
+
+// planSpec is an issue described in one of the YAML files, it is matched to
+// the issue in Jira with the ID label.
+type planSpec struct {
+	ID        string                 `json:"id" yaml:"id"`
+	Project   string                 `json:"project,omitempty" yaml:"project,omitempty"`
+	IssueType string                 `json:"issuetype,omitempty" yaml:"issuetype,omitempty"`
+	Status    string                 `json:"status,omitempty" yaml:"status,omitempty"`
+	Epic      string                 `json:"epic,omitempty" yaml:"epic,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Links     []*planSpecLink        `json:"links,omitempty" yaml:"links,omitempty"`
+	file      string
+}
+
+// planSpecLink links the issue to another spec ID or issue key.  With
+// `outward` the issue has the outward description of the link type to the
+// other issue (ie it "blocks" the other issue), with `inward` the issue has
+// the inward description (ie it "is blocked by" the other issue).
+type planSpecLink struct {
+	Type    string `json:"type" yaml:"type"`
+	Outward string `json:"outward,omitempty" yaml:"outward,omitempty"`
+	Inward  string `json:"inward,omitempty" yaml:"inward,omitempty"`
+}
+
+// planData is sent to the "plan" and "apply" templates.
+type planData struct {
+	Directory string         `json:"directory" yaml:"directory"`
+	Actions   []*planAction  `json:"actions" yaml:"actions"`
+	Unchanged []string       `json:"unchanged" yaml:"unchanged"`
+	Counts    map[string]int `json:"counts" yaml:"counts"`
+	Applied   map[string]int `json:"applied,omitempty" yaml:"applied,omitempty"`
+	// keys has the issue key for each spec ID, empty until it is created
+	keys map[string]string
+}
+
+type planAction struct {
+	// Action is one of "create", "update", "transition", "link" or "epic"
+	Action string `json:"action" yaml:"action"`
+	Spec   string `json:"spec" yaml:"spec"`
+	File   string `json:"file" yaml:"file"`
+	// Issue is empty for issues that will be created, until they are
+	Issue   string       `json:"issue" yaml:"issue"`
+	Message string       `json:"message" yaml:"message"`
+	Fields  []*diffField `json:"fields" yaml:"fields"`
+	// Target is the status for a transition, or the issue key or spec ID
+	// of the other issue for a link or epic
+	Target   string `json:"target,omitempty" yaml:"target,omitempty"`
+	LinkType string `json:"linkType,omitempty" yaml:"linkType,omitempty"`
+	Applied  bool   `json:"applied" yaml:"applied"`
+	// Skipped is set when the action was not needed when applied, like a
+	// transition to the status a new issue was created with
+	Skipped bool `json:"skipped" yaml:"skipped"`
+	spec    *planSpec
+	update  map[string]interface{}
+	outward bool
+}
+
+func CmdPlanRegistry() *jiracli.CommandRegistryEntry {
+	opts := PlanOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("plan"),
+		},
+		IDLabelPrefix: "spec:",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Show the changes needed to make the issues match the YAML specs in a directory",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPlanUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdPlan(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPlanUsage(cmd *kingpin.CmdClause, opts *PlanOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "project for the specs that do not set one").Short('p').StringVar(&opts.Project)
+	cmd.Flag("id-label-prefix", "prefix of the label used to match a spec ID to an issue").StringVar(&opts.IDLabelPrefix)
+	cmd.Arg("DIR", "directory with the issue YAML specs").Required().StringVar(&opts.Directory)
+	return nil
+}
+
+// CmdPlan will compare the issue specs with the issues in Jira and send the
+// actions that `jira apply` would run to the "plan" template.
+func CmdPlan(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PlanOptions) error {
+	data, err := issuePlan(o, globals, opts.Directory, opts.Project, opts.IDLabelPrefix)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
+
+// readPlanSpecs reads the specs from the .yml and .yaml files under the
+// directory, a file can have one spec or a list of specs.
+func readPlanSpecs(dir string) ([]*planSpec, error) {
+	specs := []*planSpec{}
+	ids := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); info.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var raw interface{}
+		if err := jiracli.ParseEditYAML(content, &raw); err != nil {
+			return fmt.Errorf("Unable to parse %s: %s", path, err)
+		}
+		if _, ok := raw.([]interface{}); !ok {
+			raw = []interface{}{raw}
+		}
+		found := []*planSpec{}
+		if err := jiracli.ConvertType(raw, &found); err != nil {
+			return fmt.Errorf("Invalid spec in %s: %s", path, err)
+		}
+		for _, spec := range found {
+			if spec == nil {
+				continue
+			}
+			if spec.ID == "" {
+				return fmt.Errorf("A spec in %s is missing the id", path)
+			}
+			if other, ok := ids[spec.ID]; ok {
+				return fmt.Errorf("The spec %q is in %s and %s", spec.ID, other, path)
+			}
+			ids[spec.ID] = path
+			spec.file = path
+			specs = append(specs, spec)
+		}
+		return nil
+	})
+	return specs, err
+}
+
+// issuePlan reads the specs and finds the issues for them, returning the
+// actions needed to create the missing issues and update the others.
+func issuePlan(o *oreo.Client, globals *jiracli.GlobalOptions, dir, project, prefix string) (*planData, error) {
+	specs, err := readPlanSpecs(dir)
+	if err != nil {
+		return nil, jiracli.CliError(err)
+	}
+	fields, err := jira.GetFields(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := planIssues(o, globals, specs, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return planSpecs(dir, specs, issues, fields, project, prefix)
+}
+
+// planIssues finds the issues with the ID labels of the specs, by spec ID.
+func planIssues(o *oreo.Client, globals *jiracli.GlobalOptions, specs []*planSpec, prefix string) (map[string]*jiradata.Issue, error) {
+	issues := map[string]*jiradata.Issue{}
+	for start := 0; start < len(specs); start += 50 {
+		labels := []string{}
+		for _, spec := range specs[start:planMin(start+50, len(specs))] {
+			labels = append(labels, fmt.Sprintf("%q", prefix+spec.ID))
+		}
+		results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query:       fmt.Sprintf("labels in (%s)", strings.Join(labels, ", ")),
+			QueryFields: "*all",
+		}, jira.WithAutoPagination())
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range results.Issues {
+			values, _ := issue.Fields["labels"].([]interface{})
+			for _, value := range values {
+				label, _ := value.(string)
+				if !strings.HasPrefix(label, prefix) {
+					continue
+				}
+				id := strings.TrimPrefix(label, prefix)
+				if other, ok := issues[id]; ok && other.Key != issue.Key {
+					return nil, jiracli.CliError(fmt.Errorf("The spec %q matches both %s and %s", id, other.Key, issue.Key))
+				}
+				issues[id] = issue
+			}
+		}
+	}
+	return issues, nil
+}
+
+// planSpecs compares the specs with the issues found for them and returns
+// the actions needed, creates go first so the later actions can use the new
+// issue keys.
+func planSpecs(dir string, specs []*planSpec, issues map[string]*jiradata.Issue, fields []jiradata.Field, project, prefix string) (*planData, error) {
+	epicLink := ""
+	for _, field := range fields {
+		if field.Name == "Epic Link" {
+			epicLink = field.ID
+		}
+	}
+
+	data := &planData{
+		Directory: dir,
+		Actions:   []*planAction{},
+		Unchanged: []string{},
+		Counts:    map[string]int{"create": 0, "update": 0, "transition": 0, "link": 0, "epic": 0},
+	}
+	data.keys = map[string]string{}
+	for _, spec := range specs {
+		data.keys[spec.ID] = ""
+		if issue, ok := issues[spec.ID]; ok {
+			data.keys[spec.ID] = issue.Key
+		}
+	}
+	refKey := data.refKey
+
+	ordered := map[string][]*planAction{}
+	for _, spec := range specs {
+		desired := map[string]interface{}{}
+		for name, value := range spec.Fields {
+			id, err := jiracli.FieldID(name)
+			if err != nil {
+				return nil, jiracli.CliError(fmt.Errorf("The spec %q in %s: %s", spec.ID, spec.file, err))
+			}
+			desired[id] = value
+		}
+		// keep the ID label when the spec has labels
+		if labels, ok := desired["labels"].([]interface{}); ok && !planHasLabel(labels, prefix+spec.ID) {
+			desired["labels"] = append(labels, prefix+spec.ID)
+		}
+
+		issue, exists := issues[spec.ID]
+		if !exists {
+			if _, ok := desired["labels"]; !ok {
+				desired["labels"] = []interface{}{prefix + spec.ID}
+			}
+			if spec.Project == "" {
+				spec.Project = project
+			}
+			if spec.Project == "" || spec.IssueType == "" || desired["summary"] == nil {
+				return nil, jiracli.CliError(fmt.Errorf("The spec %q in %s needs a project, issuetype and summary to create the issue", spec.ID, spec.file))
+			}
+			desired["project"] = map[string]interface{}{"key": spec.Project}
+			desired["issuetype"] = map[string]interface{}{"name": spec.IssueType}
+			summary, _ := desired["summary"].(string)
+			action := &planAction{
+				Action:  "create",
+				Message: fmt.Sprintf("create %s %s %q", spec.Project, spec.IssueType, summary),
+				update:  desired,
+			}
+			for _, id := range planSortedKeys(desired) {
+				action.Fields = append(action.Fields, &diffField{ID: id, Name: planFieldName(fields, id), Lines: diffLines(nil, diffValues(desired[id], desired[id]))})
+			}
+			ordered["create"] = append(ordered["create"], planNewAction(action, spec))
+		} else {
+			changed := map[string]interface{}{}
+			action := &planAction{Action: "update", Issue: issue.Key, Message: fmt.Sprintf("update %s", issue.Key)}
+			for _, id := range planSortedKeys(desired) {
+				current := diffValues(issue.Fields[id], desired[id])
+				wanted := diffValues(desired[id], desired[id])
+				if _, list := desired[id].([]interface{}); list {
+					// the order of labels, components and versions does not matter
+					sort.Strings(current)
+					sort.Strings(wanted)
+				}
+				lines := diffLines(current, wanted)
+				for _, line := range lines {
+					if line.Op != " " {
+						changed[id] = desired[id]
+						action.Fields = append(action.Fields, &diffField{ID: id, Name: planFieldName(fields, id), Lines: lines})
+						break
+					}
+				}
+			}
+			if len(changed) > 0 {
+				action.update = changed
+				ordered["update"] = append(ordered["update"], planNewAction(action, spec))
+			}
+		}
+
+		key := ""
+		if exists {
+			key = issue.Key
+		}
+		if spec.Status != "" {
+			current := ""
+			if exists {
+				if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+					current, _ = status["name"].(string)
+				}
+			}
+			if !strings.EqualFold(current, spec.Status) {
+				message := fmt.Sprintf("transition %s to %s", planIssueName(key, spec), spec.Status)
+				if current != "" {
+					message = fmt.Sprintf("transition %s from %s to %s", key, current, spec.Status)
+				}
+				ordered["transition"] = append(ordered["transition"], planNewAction(&planAction{
+					Action:  "transition",
+					Issue:   key,
+					Message: message,
+					Target:  spec.Status,
+				}, spec))
+			}
+		}
+		if spec.Epic != "" {
+			epicKey := refKey(spec.Epic)
+			if !exists || epicKey == "" || issueEpicKey(issue, epicLink) != epicKey {
+				ordered["epic"] = append(ordered["epic"], planNewAction(&planAction{
+					Action:  "epic",
+					Issue:   key,
+					Message: fmt.Sprintf("add %s to epic %s", planIssueName(key, spec), planRefName(spec.Epic, epicKey)),
+					Target:  spec.Epic,
+				}, spec))
+			}
+		}
+		for _, link := range spec.Links {
+			if link.Type == "" || (link.Outward == "") == (link.Inward == "") {
+				return nil, jiracli.CliError(fmt.Errorf("A link for the spec %q in %s needs a type and one of outward or inward", spec.ID, spec.file))
+			}
+			ref, outward := link.Outward, true
+			if ref == "" {
+				ref, outward = link.Inward, false
+			}
+			otherKey := refKey(ref)
+			if exists && otherKey != "" && planLinked(issue, link.Type, otherKey, outward) {
+				continue
+			}
+			direction := "outward"
+			if !outward {
+				direction = "inward"
+			}
+			ordered["link"] = append(ordered["link"], planNewAction(&planAction{
+				Action:   "link",
+				Issue:    key,
+				Message:  fmt.Sprintf("link %s %s %s (%s)", planIssueName(key, spec), link.Type, planRefName(ref, otherKey), direction),
+				Target:   ref,
+				LinkType: link.Type,
+				outward:  outward,
+			}, spec))
+		}
+
+		if exists && !planHasActions(ordered, spec) {
+			data.Unchanged = append(data.Unchanged, issue.Key)
+		}
+	}
+
+	for _, kind := range []string{"create", "update", "transition", "epic", "link"} {
+		data.Actions = append(data.Actions, ordered[kind]...)
+		data.Counts[kind] = len(ordered[kind])
+	}
+	sort.Strings(data.Unchanged)
+	return data, nil
+}
+
+// refKey returns the issue key for a reference to the ID of another spec or
+// an issue key, it is empty for a spec that has not been created.
+func (data *planData) refKey(ref string) string {
+	if key, ok := data.keys[ref]; ok {
+		return key
+	}
+	return ref
+}
+
+func planNewAction(action *planAction, spec *planSpec) *planAction {
+	action.Spec = spec.ID
+	action.File = spec.file
+	action.spec = spec
+	return action
+}
+
+func planHasActions(ordered map[string][]*planAction, spec *planSpec) bool {
+	for _, actions := range ordered {
+		for _, action := range actions {
+			if action.spec == spec {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func planHasLabel(labels []interface{}, label string) bool {
+	for _, value := range labels {
+		if value == label {
+			return true
+		}
+	}
+	return false
+}
+
+// planLinked returns true when the issue already has the link to the other
+// issue in the direction.
+func planLinked(issue *jiradata.Issue, linkType, other string, outward bool) bool {
+	side := "inwardIssue"
+	if outward {
+		side = "outwardIssue"
+	}
+	links, _ := issue.Fields["issuelinks"].([]interface{})
+	for _, data := range links {
+		link, _ := data.(map[string]interface{})
+		kind, _ := link["type"].(map[string]interface{})
+		linked, _ := link[side].(map[string]interface{})
+		if kind != nil && linked != nil && strings.EqualFold(fmt.Sprint(kind["name"]), linkType) && linked["key"] == other {
+			return true
+		}
+	}
+	return false
+}
+
+// planIssueName is the issue key, or the spec ID for a new issue
+func planIssueName(key string, spec *planSpec) string {
+	if key != "" {
+		return key
+	}
+	return spec.ID
+}
+
+func planRefName(ref, key string) string {
+	if key != "" {
+		return key
+	}
+	return ref
+}
+
+func planFieldName(fields []jiradata.Field, id string) string {
+	for _, field := range fields {
+		if field.ID == id {
+			return field.Name
+		}
+	}
+	return id
+}
+
+func planSortedKeys(values map[string]interface{}) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func planMin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
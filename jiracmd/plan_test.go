@@ -0,0 +1,210 @@
+package jiracmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPlanSpecs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-plan")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	write("login.yml", "id: login\nissuetype: Story\nfields:\n  summary: Login page\n")
+	write("epics/auth.yaml", "- id: auth\n  issuetype: Epic\n  fields:\n    summary: Auth\n- id: sso\n  epic: auth\n  links:\n    - type: Blocks\n      inward: login\n")
+	write("README.md", "id: ignored\n")
+	specs, err := readPlanSpecs(dir)
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, spec := range specs {
+		ids = append(ids, spec.ID)
+	}
+	assert.Equal(t, []string{"auth", "sso", "login"}, ids)
+	assert.Equal(t, filepath.Join(dir, "epics/auth.yaml"), specs[1].file)
+	assert.Equal(t, "auth", specs[1].Epic)
+	assert.Equal(t, []*planSpecLink{{Type: "Blocks", Inward: "login"}}, specs[1].Links)
+	assert.Equal(t, map[string]interface{}{"summary": "Login page"}, specs[2].Fields)
+
+	write("more.yml", "id: login\n")
+	_, err = readPlanSpecs(dir)
+	assert.EqualError(t, err, `The spec "login" is in `+filepath.Join(dir, "login.yml")+" and "+filepath.Join(dir, "more.yml"))
+
+	write("more.yml", "issuetype: Bug\n")
+	_, err = readPlanSpecs(dir)
+	assert.EqualError(t, err, "A spec in "+filepath.Join(dir, "more.yml")+" is missing the id")
+
+	write("more.yml", "id: [broken\n")
+	_, err = readPlanSpecs(dir)
+	assert.Error(t, err)
+}
+
+func TestPlanSpecs(t *testing.T) {
+	fields := []jiradata.Field{
+		{ID: "summary", Name: "Summary"},
+		{ID: "labels", Name: "Labels"},
+		{ID: "customfield_10014", Name: "Epic Link"},
+	}
+	issue := func(key, status string, fields map[string]interface{}) *jiradata.Issue {
+		fields["status"] = map[string]interface{}{"name": status}
+		return &jiradata.Issue{Key: key, Fields: fields}
+	}
+	login := func() *jiradata.Issue {
+		return issue("TEST-1", "To Do", map[string]interface{}{
+			"summary": "Login page",
+			"labels":  []interface{}{"ui", "spec:login"},
+			"issuelinks": []interface{}{map[string]interface{}{
+				"type":         map[string]interface{}{"name": "Blocks"},
+				"outwardIssue": map[string]interface{}{"key": "TEST-2"},
+			}},
+			"customfield_10014": "TEST-9",
+		})
+	}
+
+	for name, test := range map[string]struct {
+		specs     []*planSpec
+		issues    map[string]*jiradata.Issue
+		messages  []string
+		unchanged []string
+		err       string
+	}{
+		"create": {
+			specs: []*planSpec{
+				{ID: "login", IssueType: "Story", Status: "In Progress", Fields: map[string]interface{}{"summary": "Login page"}},
+			},
+			messages: []string{
+				`create TEST Story "Login page"`,
+				"transition login to In Progress",
+			},
+		},
+		"create needs a summary": {
+			specs: []*planSpec{{ID: "login", IssueType: "Story"}},
+			err:   `The spec "login" in login.yml needs a project, issuetype and summary to create the issue`,
+		},
+		"unchanged": {
+			specs: []*planSpec{
+				{ID: "login", Status: "to do", Epic: "TEST-9", Fields: map[string]interface{}{"summary": "Login page", "labels": []interface{}{"ui"}},
+					Links: []*planSpecLink{{Type: "blocks", Outward: "TEST-2"}}},
+			},
+			issues:    map[string]*jiradata.Issue{"login": login()},
+			unchanged: []string{"TEST-1"},
+		},
+		"unchanged with the ID label in the spec": {
+			specs: []*planSpec{
+				{ID: "login", Fields: map[string]interface{}{"labels": []interface{}{"spec:login", "ui"}}},
+			},
+			issues:    map[string]*jiradata.Issue{"login": login()},
+			unchanged: []string{"TEST-1"},
+		},
+		"update": {
+			specs: []*planSpec{
+				{ID: "login", Status: "Done", Fields: map[string]interface{}{"summary": "Login page v2", "labels": []interface{}{"ui"}}},
+			},
+			issues: map[string]*jiradata.Issue{"login": login()},
+			messages: []string{
+				"update TEST-1",
+				"transition TEST-1 from To Do to Done",
+			},
+		},
+		"epic": {
+			specs: []*planSpec{
+				{ID: "auth", IssueType: "Epic", Fields: map[string]interface{}{"summary": "Auth"}},
+				{ID: "login", Epic: "auth"},
+				{ID: "logout", Epic: "TEST-9", IssueType: "Story", Fields: map[string]interface{}{"summary": "Logout"}},
+			},
+			issues: map[string]*jiradata.Issue{"login": login()},
+			messages: []string{
+				`create TEST Epic "Auth"`,
+				`create TEST Story "Logout"`,
+				"add TEST-1 to epic auth",
+				"add logout to epic TEST-9",
+			},
+		},
+		"links": {
+			specs: []*planSpec{
+				{ID: "login", Links: []*planSpecLink{{Type: "Blocks", Outward: "TEST-2"}, {Type: "Blocks", Inward: "TEST-2"}, {Type: "Relates", Outward: "sso"}}},
+				{ID: "sso", IssueType: "Story", Fields: map[string]interface{}{"summary": "SSO"}},
+			},
+			issues: map[string]*jiradata.Issue{"login": login()},
+			messages: []string{
+				`create TEST Story "SSO"`,
+				"link TEST-1 Blocks TEST-2 (inward)",
+				"link TEST-1 Relates sso (outward)",
+			},
+		},
+		"link needs a direction": {
+			specs:  []*planSpec{{ID: "login", Links: []*planSpecLink{{Type: "Blocks", Outward: "TEST-2", Inward: "TEST-3"}}}},
+			issues: map[string]*jiradata.Issue{"login": login()},
+			err:    `A link for the spec "login" in login.yml needs a type and one of outward or inward`,
+		},
+	} {
+		for _, spec := range test.specs {
+			spec.file = spec.ID + ".yml"
+		}
+		if test.issues == nil {
+			test.issues = map[string]*jiradata.Issue{}
+		}
+		data, err := planSpecs("specs", test.specs, test.issues, fields, "TEST", "spec:")
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, name)
+			continue
+		}
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		messages := []string{}
+		for _, action := range data.Actions {
+			messages = append(messages, action.Message)
+		}
+		if test.messages == nil {
+			test.messages = []string{}
+		}
+		if test.unchanged == nil {
+			test.unchanged = []string{}
+		}
+		assert.Equal(t, test.messages, messages, name)
+		assert.Equal(t, test.unchanged, data.Unchanged, name)
+	}
+}
+
+func TestPlanSpecsUpdates(t *testing.T) {
+	specs := []*planSpec{
+		{ID: "login", Fields: map[string]interface{}{"summary": "Login page v2", "labels": []interface{}{"ui", "spec:login"}}},
+		{ID: "sso", IssueType: "Story", Fields: map[string]interface{}{"summary": "SSO"}},
+	}
+	for _, spec := range specs {
+		spec.file = spec.ID + ".yml"
+	}
+	issues := map[string]*jiradata.Issue{
+		"login": {Key: "TEST-1", Fields: map[string]interface{}{"summary": "Login page", "labels": []interface{}{"spec:login", "ui"}}},
+	}
+	data, err := planSpecs("specs", specs, issues, nil, "TEST", "spec:")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"create": 1, "update": 1, "transition": 0, "link": 0, "epic": 0}, data.Counts)
+
+	// the new issue gets the ID label, the update only has the changed fields
+	assert.Equal(t, map[string]interface{}{
+		"summary":   "SSO",
+		"labels":    []interface{}{"spec:sso"},
+		"project":   map[string]interface{}{"key": "TEST"},
+		"issuetype": map[string]interface{}{"name": "Story"},
+	}, data.Actions[0].update)
+	assert.Equal(t, map[string]interface{}{"summary": "Login page v2"}, data.Actions[1].update)
+	assert.Equal(t, "TEST-1", data.Actions[1].Issue)
+	assert.Equal(t, []*diffLine{{"-", "Login page"}, {"+", "Login page v2"}}, data.Actions[1].Fields[0].Lines)
+
+	// the keys of the specs that are not created yet are empty
+	assert.Equal(t, map[string]string{"login": "TEST-1", "sso": ""}, data.keys)
+	assert.Equal(t, "TEST-1", data.refKey("login"))
+	assert.Equal(t, "", data.refKey("sso"))
+	assert.Equal(t, "TEST-5", data.refKey("TEST-5"))
+}
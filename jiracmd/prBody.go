@@ -0,0 +1,136 @@
+package jiracmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PrBodyOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	URL                   string `yaml:"url,omitempty" json:"url,omitempty"`
+	Title                 string `yaml:"title,omitempty" json:"title,omitempty"`
+	CriteriaField         string `yaml:"criteria-field,omitempty" json:"criteria-field,omitempty"`
+}
+
+// prBodyTemplateInput is the issue data augmented with the epic and the
+// other details that are useful in a pull request, sent to the "pr" template.
+type prBodyTemplateInput struct {
+	*jiradata.Issue    `yaml:",inline"`
+	Endpoint           string                    `yaml:"endpoint" json:"endpoint"`
+	Epic               *jiradata.Issue           `yaml:"epic,omitempty" json:"epic,omitempty"`
+	EpicIssues         jiradata.Issues           `yaml:"epicIssues,omitempty" json:"epicIssues,omitempty"`
+	AcceptanceCriteria string                    `yaml:"acceptanceCriteria,omitempty" json:"acceptanceCriteria,omitempty"`
+	RemoteLinks        jiradata.RemoteIssueLinks `yaml:"remotelinks,omitempty" json:"remotelinks,omitempty"`
+	PullRequest        string                    `yaml:"pullRequest,omitempty" json:"pullRequest,omitempty"`
+}
+
+func CmdPrBodyRegistry() *jiracli.CommandRegistryEntry {
+	opts := PrBodyOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("pr"),
+		},
+		Title:         "Pull Request",
+		CriteriaField: "Acceptance Criteria",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Print a pull request description for an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPrBodyUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdPrBody(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPrBodyUsage(cmd *kingpin.CmdClause, opts *PrBodyOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("url", "Pull request URL to add as a remote link on the issue").StringVar(&opts.URL)
+	cmd.Flag("title", "Title for the pull request remote link").StringVar(&opts.Title)
+	cmd.Flag("criteria-field", "Name or id of the acceptance criteria field").StringVar(&opts.CriteriaField)
+	jiracli.IssueArgUsage(cmd, "issue to describe", &opts.Issue)
+	return nil
+}
+
+// CmdPrBody will render the issue with its epic, sub-tasks and links through
+// the "pr" template, and link the pull request to the issue when given the url.
+func CmdPrBody(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PrBodyOptions) error {
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, nil)
+	if err != nil {
+		return err
+	}
+
+	data := &prBodyTemplateInput{
+		Issue:       issue,
+		Endpoint:    strings.TrimSuffix(globals.Endpoint.Value, "/"),
+		PullRequest: opts.URL,
+	}
+
+	epicLink, err := jiracli.FieldID("Epic Link")
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	criteria, err := jiracli.FieldID(opts.CriteriaField)
+	if err != nil {
+		return jiracli.CliError(err)
+	}
+	data.AcceptanceCriteria, _ = issue.Fields[criteria].(string)
+	if epic := issueEpicKey(issue, epicLink); epic != "" {
+		if data.Epic, err = jira.GetIssue(o, globals.Endpoint.Value, epic, &jira.IssueOptions{Fields: []string{"summary", "status"}}); err != nil {
+			return err
+		}
+	}
+
+	if issuetype, ok := issue.Fields["issuetype"].(map[string]interface{}); ok && issuetype["name"] == "Epic" {
+		results, err := jira.EpicSearch(o, globals.Endpoint.Value, issue.Key, &jira.SearchOptions{
+			Query:       "ORDER BY key",
+			QueryFields: "status,issuetype",
+		})
+		if err != nil {
+			return err
+		}
+		data.EpicIssues = results.Issues
+	}
+
+	if links, err := jira.GetRemoteLinks(o, globals.Endpoint.Value, issue.Key); err != nil {
+		log.Debugf("Unable to fetch remote links for %s: %s", issue.Key, err)
+	} else {
+		data.RemoteLinks = *links
+	}
+
+	if opts.URL != "" {
+		link := jiradata.RemoteIssueLink{
+			// using the url as the global id means running this again
+			// will update the link rather than add another
+			GlobalID: opts.URL,
+			Object: &jiradata.RemoteObject{
+				URL:   opts.URL,
+				Title: opts.Title,
+			},
+		}
+		resp, err := jira.CreateRemoteLink(o, globals.Endpoint.Value, issue.Key, &link)
+		if err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			// the description is usually piped to another command, so keep
+			// the status off of stdout
+			fmt.Fprintf(os.Stderr, "OK %s %d %s\n", issue.Key, resp.ID, opts.URL)
+		}
+	}
+
+	return opts.PrintTemplate(data)
+}
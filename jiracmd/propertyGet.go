@@ -0,0 +1,84 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	IssuePropertyEntity   = "issue"
+	ProjectPropertyEntity = "project"
+)
+
+type PropertyGetOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Entity                string `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key                   string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+func CmdPropertyGetRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyGetOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("property"),
+		},
+		Entity: IssuePropertyEntity,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the value of an issue or project property",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyGetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Entity == IssuePropertyEntity {
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
+			return CmdPropertyGet(o, globals, &opts)
+		},
+	}
+}
+
+func propertyEntityUsage(cmd *kingpin.CmdClause, entity *string) {
+	cmd.Flag("entity", "Type of entity the property belongs to, ISSUE is a project key for 'project'").EnumVar(entity, IssuePropertyEntity, ProjectPropertyEntity)
+}
+
+// propertyIssueArgUsage adds the ISSUE argument, the issue picker is only
+// used for issue properties since ISSUE is a project key for 'project'.
+func propertyIssueArgUsage(cmd *kingpin.CmdClause, help string, issue, entity *string) {
+	jiracli.IssueArgUsage(cmd, help, issue)
+	jiracli.SkipIssuePicker(cmd, func() bool { return *entity == ProjectPropertyEntity })
+}
+
+func CmdPropertyGetUsage(cmd *kingpin.CmdClause, opts *PropertyGetOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	propertyEntityUsage(cmd, &opts.Entity)
+	propertyIssueArgUsage(cmd, "issue to get property from", &opts.Issue, &opts.Entity)
+	jiracli.ArgAfterIssueUsage(cmd, "KEY", "property key", &opts.Key)
+	return nil
+}
+
+// CmdPropertyGet will fetch a property and send it to the "property" template
+func CmdPropertyGet(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyGetOptions) error {
+	getProperty := jira.GetIssueProperty
+	switch opts.Entity {
+	case IssuePropertyEntity:
+	case ProjectPropertyEntity:
+		getProperty = jira.GetProjectProperty
+	default:
+		return fmt.Errorf("Unknown property entity %q", opts.Entity)
+	}
+	data, err := getProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
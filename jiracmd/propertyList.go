@@ -0,0 +1,66 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertyListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Entity                string `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdPropertyListRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("properties"),
+		},
+		Entity: IssuePropertyEntity,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the property keys of an issue or project",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Entity == IssuePropertyEntity {
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
+			return CmdPropertyList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertyListUsage(cmd *kingpin.CmdClause, opts *PropertyListOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	propertyEntityUsage(cmd, &opts.Entity)
+	propertyIssueArgUsage(cmd, "issue to list properties", &opts.Issue, &opts.Entity)
+	return nil
+}
+
+// CmdPropertyList will fetch the property keys and send them to the "properties" template
+func CmdPropertyList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyListOptions) error {
+	getPropertyKeys := jira.GetIssuePropertyKeys
+	switch opts.Entity {
+	case IssuePropertyEntity:
+	case ProjectPropertyEntity:
+		getPropertyKeys = jira.GetProjectPropertyKeys
+	default:
+		return fmt.Errorf("Unknown property entity %q", opts.Entity)
+	}
+	data, err := getPropertyKeys(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -0,0 +1,66 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertyRemoveOptions struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Entity  string `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Issue   string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+func CmdPropertyRemoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyRemoveOptions{
+		Entity: IssuePropertyEntity,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete an issue or project property",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyRemoveUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Entity == IssuePropertyEntity {
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
+			return CmdPropertyRemove(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertyRemoveUsage(cmd *kingpin.CmdClause, opts *PropertyRemoveOptions) error {
+	propertyEntityUsage(cmd, &opts.Entity)
+	propertyIssueArgUsage(cmd, "issue to remove property from", &opts.Issue, &opts.Entity)
+	jiracli.ArgAfterIssueUsage(cmd, "KEY", "property key", &opts.Key)
+	return nil
+}
+
+// CmdPropertyRemove will delete a property
+func CmdPropertyRemove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyRemoveOptions) error {
+	deleteProperty := jira.DeleteIssueProperty
+	switch opts.Entity {
+	case IssuePropertyEntity:
+	case ProjectPropertyEntity:
+		deleteProperty = jira.DeleteProjectProperty
+	default:
+		return fmt.Errorf("Unknown property entity %q", opts.Entity)
+	}
+
+	if err := deleteProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK Deleted Property %s from %s\n", opts.Key, opts.Issue)
+	}
+	return nil
+}
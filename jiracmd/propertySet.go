@@ -0,0 +1,90 @@
+package jiracmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertySetOptions struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Entity  string `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Issue   string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`
+	Value   string `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+func CmdPropertySetRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertySetOptions{
+		Entity: IssuePropertyEntity,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Set the value of an issue or project property",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertySetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Entity == IssuePropertyEntity {
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
+			return CmdPropertySet(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertySetUsage(cmd *kingpin.CmdClause, opts *PropertySetOptions) error {
+	propertyEntityUsage(cmd, &opts.Entity)
+	propertyIssueArgUsage(cmd, "issue to set property on", &opts.Issue, &opts.Entity)
+	jiracli.ArgAfterIssueUsage(cmd, "KEY", "property key", &opts.Key)
+	cmd.Arg("JSON", "JSON value for property, if not provided read from stdin").StringVar(&opts.Value)
+	return nil
+}
+
+// CmdPropertySet will store the JSON value as a property
+func CmdPropertySet(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertySetOptions) error {
+	setProperty := jira.SetIssueProperty
+	switch opts.Entity {
+	case IssuePropertyEntity:
+	case ProjectPropertyEntity:
+		setProperty = jira.SetProjectProperty
+	default:
+		return fmt.Errorf("Unknown property entity %q", opts.Entity)
+	}
+
+	raw := []byte(opts.Value)
+	if opts.Value == "" {
+		if terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("JSON argument required or redirect from STDIN")
+		}
+		var err error
+		raw, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("Invalid JSON value for property %q (strings must be quoted): %s", opts.Key, err)
+	}
+
+	if err := setProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key, value); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, opts.Key)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import "github.com/go-jira/jira/jiracli"
 
 func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "acknowledge", Entry: CmdTransitionRegistry("acknowledge"), Aliases: []string{"ack"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "apply", Entry: CmdApplyRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "assign", Entry: CmdAssignRegistry(), Aliases: []string{"give"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "attach create", Entry: CmdAttachCreateRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "attach get", Entry: CmdAttachGetRegistry()})
@@ -12,13 +13,17 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "backlog", Entry: CmdTransitionRegistry("Backlog")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "block", Entry: CmdBlockRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "browse", Entry: CmdBrowseRegistry(), Aliases: []string{"b"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "cache clear", Entry: CmdCacheClearRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "cache show", Entry: CmdCacheShowRegistry(), Aliases: []string{"ls"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "close", Entry: CmdTransitionRegistry("close")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment", Entry: CmdCommentRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "component add", Entry: CmdComponentAddRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "components", Entry: CmdComponentsRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "completion", Entry: CmdCompletionRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "create", Entry: CmdCreateRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "createmeta", Entry: CmdCreateMetaRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "done", Entry: CmdTransitionRegistry("Done")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "diff", Entry: CmdDiffRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "dup", Entry: CmdDupRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "edit", Entry: CmdEditRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "editmeta", Entry: CmdEditMetaRegistry()})
@@ -28,6 +33,16 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "epic remove", Entry: CmdEpicRemoveRegistry(), Aliases: []string{"rm"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "export-templates", Entry: CmdExportTemplatesRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "fields", Entry: CmdFieldsRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "filter create", Entry: CmdFilterCreateRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "filter list", Entry: CmdFilterListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "filter show", Entry: CmdFilterShowRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "filter update", Entry: CmdFilterUpdateRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "git branch", Entry: CmdGitBranchRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "git commit-msg", Entry: CmdGitCommitMsgRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "git current", Entry: CmdGitCurrentRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "git post-commit", Entry: CmdGitPostCommitRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "graph", Entry: CmdGraphRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "group members", Entry: CmdGroupMembersRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "in-progress", Entry: CmdTransitionRegistry("Progress"), Aliases: []string{"prog", "progress"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "issuelink", Entry: CmdIssueLinkRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "issuelinktypes", Entry: CmdIssueLinkTypesRegistry()})
@@ -38,23 +53,45 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "list", Entry: CmdListRegistry(), Aliases: []string{"ls"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "login", Entry: CmdLoginRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "logout", Entry: CmdLogoutRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "myself", Entry: CmdMyselfRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "plan", Entry: CmdPlanRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "pr-body", Entry: CmdPrBodyRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property get", Entry: CmdPropertyGetRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property list", Entry: CmdPropertyListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property remove", Entry: CmdPropertyRemoveRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property set", Entry: CmdPropertySetRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "rank", Entry: CmdRankRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink add", Entry: CmdRemoteLinkAddRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink list", Entry: CmdRemoteLinkListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink remove", Entry: CmdRemoteLinkRemoveRegistry(), Aliases: []string{"rm"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "reopen", Entry: CmdTransitionRegistry("reopen")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "report standup", Entry: CmdReportStandupRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "report weekly", Entry: CmdReportWeeklyRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "request", Entry: CmdRequestRegistry(), Aliases: []string{"req"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "resolve", Entry: CmdTransitionRegistry("resolve")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "serve-webhooks", Entry: CmdServeWebhooksRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "start", Entry: CmdTransitionRegistry("start")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "stop", Entry: CmdTransitionRegistry("stop")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "subtask", Entry: CmdSubtaskRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "sync", Entry: CmdSyncRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "take", Entry: CmdTakeRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "template check", Entry: CmdTemplateCheckRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "template render", Entry: CmdTemplateRenderRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "todo", Entry: CmdTransitionRegistry("To Do")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transition", Entry: CmdTransitionRegistry(""), Aliases: []string{"trans"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transitions", Entry: CmdTransitionsRegistry("transitions")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transmeta", Entry: CmdTransitionsRegistry("debug")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "tree", Entry: CmdTreeRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "tui", Entry: CmdTuiRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "unassign", Entry: CmdUnassignRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "unexport-templates", Entry: CmdUnexportTemplatesRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "user search", Entry: CmdUserSearchRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "user show", Entry: CmdUserShowRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "view", Entry: CmdViewRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "vote", Entry: CmdVoteRegistry()})
-	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "watch", Entry: CmdWatchRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "watch add", Entry: CmdWatchRegistry(), Default: true})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "watch list", Entry: CmdWatchListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "watch-query", Entry: CmdWatchQueryRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog add", Entry: CmdWorklogAddRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog list", Entry: CmdWorklogListRegistry(), Default: true})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "session", Entry: CmdSessionRegistry()})
@@ -0,0 +1,81 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type RemoteLinkAddOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	URL                   string `yaml:"url,omitempty" json:"url,omitempty"`
+	Title                 string `yaml:"title,omitempty" json:"title,omitempty"`
+	Summary               string `yaml:"summary,omitempty" json:"summary,omitempty"`
+	GlobalID              string `yaml:"global-id,omitempty" json:"global-id,omitempty"`
+	Relationship          string `yaml:"relationship,omitempty" json:"relationship,omitempty"`
+}
+
+func CmdRemoteLinkAddRegistry() *jiracli.CommandRegistryEntry {
+	opts := RemoteLinkAddOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Add a remote (web) link to an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdRemoteLinkAddUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdRemoteLinkAdd(o, globals, &opts)
+		},
+	}
+}
+
+func CmdRemoteLinkAddUsage(cmd *kingpin.CmdClause, opts *RemoteLinkAddOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("title", "Title for the link, defaults to the URL").StringVar(&opts.Title)
+	cmd.Flag("summary", "Summary text for the link").StringVar(&opts.Summary)
+	cmd.Flag("global-id", "Global id for the link, links with an existing global id are updated").StringVar(&opts.GlobalID)
+	cmd.Flag("relationship", "Relationship of the link to the issue").StringVar(&opts.Relationship)
+	jiracli.IssueArgUsage(cmd, "issue to add link to", &opts.Issue)
+	jiracli.ArgAfterIssueUsage(cmd, "URL", "URL to link to the issue", &opts.URL)
+	return nil
+}
+
+// CmdRemoteLinkAdd will add a remote link to the given issue
+func CmdRemoteLinkAdd(o *oreo.Client, globals *jiracli.GlobalOptions, opts *RemoteLinkAddOptions) error {
+	if opts.Title == "" {
+		opts.Title = opts.URL
+	}
+	link := jiradata.RemoteIssueLink{
+		GlobalID:     opts.GlobalID,
+		Relationship: opts.Relationship,
+		Object: &jiradata.RemoteObject{
+			URL:     opts.URL,
+			Title:   opts.Title,
+			Summary: opts.Summary,
+		},
+	}
+
+	resp, err := jira.CreateRemoteLink(o, globals.Endpoint.Value, opts.Issue, &link)
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %d %s\n", opts.Issue, resp.ID, opts.URL)
+	}
+
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type RemoteLinkRemoveOptions struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue   string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Link    string `yaml:"link,omitempty" json:"link,omitempty"`
+}
+
+func CmdRemoteLinkRemoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := RemoteLinkRemoveOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete remote (web) link from issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdRemoteLinkRemoveUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdRemoteLinkRemove(o, globals, &opts)
+		},
+	}
+}
+
+func CmdRemoteLinkRemoveUsage(cmd *kingpin.CmdClause, opts *RemoteLinkRemoveOptions) error {
+	jiracli.IssueArgUsage(cmd, "issue to remove link from", &opts.Issue)
+	jiracli.ArgAfterIssueUsage(cmd, "LINK", "Remote link id or URL to remove", &opts.Link)
+	return nil
+}
+
+// CmdRemoteLinkRemove will delete a remote link from an issue, the link can
+// be identified either by its id or by the URL it points to.
+func CmdRemoteLinkRemove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *RemoteLinkRemoveOptions) error {
+	ids := []string{}
+	if _, err := strconv.Atoi(opts.Link); err == nil {
+		ids = append(ids, opts.Link)
+	} else {
+		links, err := jira.GetRemoteLinks(o, globals.Endpoint.Value, opts.Issue)
+		if err != nil {
+			return err
+		}
+		for _, link := range *links {
+			if link.Object != nil && link.Object.URL == opts.Link {
+				ids = append(ids, strconv.Itoa(link.ID))
+			}
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("No remote link to %q found on %s", opts.Link, opts.Issue)
+		}
+	}
+
+	for _, id := range ids {
+		if err := jira.DeleteRemoteLink(o, globals.Endpoint.Value, opts.Issue, id); err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK Deleted Remote Link %s from %s\n", id, opts.Issue)
+		}
+	}
+	return nil
+}
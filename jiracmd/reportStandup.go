@@ -0,0 +1,309 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ReportStandupOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string        `yaml:"project,omitempty" json:"project,omitempty"`
+	Query                 string        `yaml:"query,omitempty" json:"query,omitempty"`
+	Since                 time.Duration `yaml:"since,omitempty" json:"since,omitempty"`
+	Author                string        `yaml:"author,omitempty" json:"author,omitempty"`
+}
+
+// reportStandupData is sent to the "report-standup" template, with the
+// activity grouped by the person that did it.
+type reportStandupData struct {
+	Since  time.Time       `json:"since" yaml:"since"`
+	Until  time.Time       `json:"until" yaml:"until"`
+	Query  string          `json:"query" yaml:"query"`
+	People []*reportPerson `json:"people" yaml:"people"`
+}
+
+type reportPerson struct {
+	Name   string         `json:"name" yaml:"name"`
+	Issues []*reportIssue `json:"issues" yaml:"issues"`
+}
+
+// reportIssue is an issue in one of the reports, Activity is only used for
+// the standup report and BlockedBy for the weekly report.
+type reportIssue struct {
+	Key       string            `json:"key" yaml:"key"`
+	Summary   string            `json:"summary" yaml:"summary"`
+	Status    string            `json:"status" yaml:"status"`
+	Assignee  string            `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	URL       string            `json:"url" yaml:"url"`
+	BlockedBy []string          `json:"blockedBy,omitempty" yaml:"blockedBy,omitempty"`
+	Activity  []*reportActivity `json:"activity,omitempty" yaml:"activity,omitempty"`
+}
+
+type reportActivity struct {
+	// Type is one of "change", "comment" or "worklog"
+	Type      string    `json:"type" yaml:"type"`
+	Time      time.Time `json:"time" yaml:"time"`
+	Author    string    `json:"author" yaml:"author"`
+	Field     string    `json:"field,omitempty" yaml:"field,omitempty"`
+	From      string    `json:"from,omitempty" yaml:"from,omitempty"`
+	To        string    `json:"to,omitempty" yaml:"to,omitempty"`
+	Comment   string    `json:"comment,omitempty" yaml:"comment,omitempty"`
+	TimeSpent string    `json:"timeSpent,omitempty" yaml:"timeSpent,omitempty"`
+	Message   string    `json:"message" yaml:"message"`
+	user      *jiradata.User
+}
+
+func CmdReportStandupRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReportStandupOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("report-standup"),
+		},
+		Since: 24 * time.Hour,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Summarise the recent changes, comments and worklogs by person",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdReportStandupUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdReportStandup(o, globals, &opts)
+		},
+	}
+}
+
+func CmdReportStandupUsage(cmd *kingpin.CmdClause, opts *ReportStandupOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "project to report on").Short('p').StringVar(&opts.Project)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to report on").Short('q').StringVar(&opts.Query)
+	cmd.Flag("since", "How far back to look for activity").DurationVar(&opts.Since)
+	// this is not --user since that is the global option for the login user
+	cmd.Flag("author", "Only report the activity of this user (not --user, that is the user to log in as)").StringVar(&opts.Author)
+	return nil
+}
+
+// CmdReportStandup will collect the changelog, comments and worklogs of the
+// issues updated recently and render what each person touched.
+func CmdReportStandup(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ReportStandupOptions) error {
+	until := time.Now()
+	data := &reportStandupData{
+		Since: until.Add(-opts.Since),
+		Until: until,
+		Query: reportQuery(opts.Query, opts.Project, fmt.Sprintf("updated >= -%dm ORDER BY updated DESC", reportMinutes(opts.Since))),
+	}
+
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query: data.Query,
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+
+	people := map[string]*reportPerson{}
+	for _, result := range results.Issues {
+		issue, err := jira.GetIssue(o, globals.Endpoint.Value, result.Key, &jira.IssueOptions{
+			Fields: []string{"summary", "status", "assignee", "comment"},
+			Expand: []string{"changelog"},
+		})
+		if err != nil {
+			return err
+		}
+		activity := reportIssueActivity(issue)
+		if worklogs, err := jira.GetIssueWorklog(o, globals.Endpoint.Value, issue.Key); err != nil {
+			log.Debugf("Unable to fetch worklogs for %s: %s", issue.Key, err)
+		} else {
+			for _, worklog := range *worklogs {
+				message := fmt.Sprintf("logged %s", worklog.TimeSpent)
+				if line := reportFirstLine(worklog.Comment); line != "" {
+					message += ": " + line
+				}
+				activity = append(activity, &reportActivity{
+					Type:      "worklog",
+					Time:      reportTime(worklog.Created),
+					Author:    reportUserName(worklog.Author),
+					user:      worklog.Author,
+					Comment:   worklog.Comment,
+					TimeSpent: worklog.TimeSpent,
+					Message:   message,
+				})
+			}
+		}
+
+		for name, activity := range reportByAuthor(activity, data.Since, until, opts.Author) {
+			entry := reportNewIssue(globals, issue)
+			entry.Activity = activity
+			if _, ok := people[name]; !ok {
+				people[name] = &reportPerson{Name: name}
+			}
+			people[name].Issues = append(people[name].Issues, entry)
+		}
+	}
+
+	data.People = []*reportPerson{}
+	for _, person := range people {
+		sort.Slice(person.Issues, func(i, j int) bool {
+			return person.Issues[i].Key < person.Issues[j].Key
+		})
+		data.People = append(data.People, person)
+	}
+	sort.Slice(data.People, func(i, j int) bool {
+		return data.People[i].Name < data.People[j].Name
+	})
+
+	return opts.PrintTemplate(data)
+}
+
+// reportIssueActivity returns the changelog and comments of the issue as
+// activity entries.
+func reportIssueActivity(issue *jiradata.Issue) []*reportActivity {
+	activity := []*reportActivity{}
+	if issue.Changelog != nil {
+		for _, history := range issue.Changelog.Histories {
+			for _, item := range history.Items {
+				message := ""
+				switch {
+				case item.FromString == "" && item.ToString == "":
+					message = fmt.Sprintf("updated %s", item.Field)
+				case item.FromString == "":
+					message = fmt.Sprintf("set %s to %s", item.Field, item.ToString)
+				case item.ToString == "":
+					message = fmt.Sprintf("cleared %s (was %s)", item.Field, item.FromString)
+				default:
+					message = fmt.Sprintf("changed %s from %s to %s", item.Field, item.FromString, item.ToString)
+				}
+				activity = append(activity, &reportActivity{
+					Type:    "change",
+					Time:    reportTime(history.Created),
+					Author:  reportUserName(history.Author),
+					user:    history.Author,
+					Field:   item.Field,
+					From:    item.FromString,
+					To:      item.ToString,
+					Message: message,
+				})
+			}
+		}
+	}
+
+	comments := jiradata.CommentsWithPagination{}
+	if err := jiracli.ConvertType(issue.Fields["comment"], &comments); err == nil {
+		for _, comment := range comments.Comments {
+			activity = append(activity, &reportActivity{
+				Type:    "comment",
+				Time:    reportTime(comment.Created),
+				Author:  reportUserName(comment.Author),
+				user:    comment.Author,
+				Comment: comment.Body,
+				Message: fmt.Sprintf("commented: %s", reportFirstLine(comment.Body)),
+			})
+		}
+	}
+	return activity
+}
+
+// reportByAuthor returns the activity between since and until grouped by the
+// person that did it, in time order.  When author is set only the activity of
+// that user is kept.
+func reportByAuthor(activity []*reportActivity, since, until time.Time, author string) map[string][]*reportActivity {
+	byAuthor := map[string][]*reportActivity{}
+	for _, act := range activity {
+		if act.Time.Before(since) || act.Time.After(until) {
+			continue
+		}
+		if author != "" && !reportUserMatch(author, act.user) {
+			continue
+		}
+		byAuthor[act.Author] = append(byAuthor[act.Author], act)
+	}
+	for _, activity := range byAuthor {
+		sort.SliceStable(activity, func(i, j int) bool {
+			return activity[i].Time.Before(activity[j].Time)
+		})
+	}
+	return byAuthor
+}
+
+// reportUserMatch returns true when the user is the name, display name,
+// email address or account id of the author.
+func reportUserMatch(user string, author *jiradata.User) bool {
+	if author == nil {
+		return false
+	}
+	for _, name := range []string{author.Name, author.Key, author.AccountID, author.EmailAddress, author.DisplayName} {
+		if name != "" && strings.EqualFold(name, user) {
+			return true
+		}
+	}
+	return false
+}
+
+func reportNewIssue(globals *jiracli.GlobalOptions, issue *jiradata.Issue) *reportIssue {
+	entry := &reportIssue{
+		Key: issue.Key,
+		URL: jira.URLJoin(globals.Endpoint.Value, "browse", issue.Key),
+	}
+	entry.Summary, _ = issue.Fields["summary"].(string)
+	if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+		entry.Status, _ = status["name"].(string)
+	}
+	entry.Assignee = fieldUserName(issue.Fields["assignee"])
+	return entry
+}
+
+// reportQuery returns the query given by the user, or the default query
+// limited to the project.
+func reportQuery(query, project, defaultQuery string) string {
+	if query != "" {
+		return query
+	}
+	if project != "" {
+		return fmt.Sprintf("project = '%s' AND %s", project, defaultQuery)
+	}
+	return defaultQuery
+}
+
+// reportMinutes returns the duration in minutes for a relative JQL date,
+// Jira does not accept fractions like -1.5h.
+func reportMinutes(d time.Duration) int {
+	minutes := int(d.Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes
+}
+
+func reportTime(value string) time.Time {
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func reportUserName(user *jiradata.User) string {
+	if user == nil {
+		return "Anonymous"
+	}
+	for _, name := range []string{user.DisplayName, user.Name, user.AccountID} {
+		if name != "" {
+			return name
+		}
+	}
+	return "Anonymous"
+}
+
+func reportFirstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
@@ -0,0 +1,114 @@
+package jiracmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	reportAlice = &jiradata.User{Name: "alice", AccountID: "u1", EmailAddress: "alice@example.com", DisplayName: "Alice Smith"}
+	reportBob   = &jiradata.User{AccountID: "u2", DisplayName: "Bob"}
+)
+
+func TestReportIssueActivity(t *testing.T) {
+	issue := &jiradata.Issue{
+		Key: "TEST-1",
+		Fields: map[string]interface{}{
+			"comment": map[string]interface{}{
+				"comments": []interface{}{
+					map[string]interface{}{
+						"author":  map[string]interface{}{"accountId": "u2", "displayName": "Bob"},
+						"body":    "\nLooks good\nbut check the tests",
+						"created": "2020-03-02T11:00:00.000+0000",
+					},
+				},
+			},
+		},
+		Changelog: &jiradata.Changelog{
+			Histories: jiradata.Histories{
+				&jiradata.ChangeHistory{
+					Author:  reportAlice,
+					Created: "2020-03-02T10:00:00.000+0000",
+					Items: jiradata.Items{
+						&jiradata.ChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"},
+						&jiradata.ChangeItem{Field: "assignee", ToString: "Alice Smith"},
+						&jiradata.ChangeItem{Field: "labels", FromString: "cli"},
+						&jiradata.ChangeItem{Field: "description"},
+					},
+				},
+			},
+		},
+	}
+
+	messages := []string{}
+	for _, act := range reportIssueActivity(issue) {
+		messages = append(messages, act.Type+" "+act.Time.Format(time.RFC3339)+" "+act.Author+": "+act.Message)
+	}
+	assert.Equal(t, []string{
+		"change 2020-03-02T10:00:00Z Alice Smith: changed status from To Do to In Progress",
+		"change 2020-03-02T10:00:00Z Alice Smith: set assignee to Alice Smith",
+		"change 2020-03-02T10:00:00Z Alice Smith: cleared labels (was cli)",
+		"change 2020-03-02T10:00:00Z Alice Smith: updated description",
+		"comment 2020-03-02T11:00:00Z Bob: commented: Looks good",
+	}, messages)
+
+	// issues without a changelog or comments have no activity
+	assert.Empty(t, reportIssueActivity(&jiradata.Issue{Key: "TEST-2", Fields: map[string]interface{}{}}))
+}
+
+func TestReportByAuthor(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2020, 3, 2, hour, 0, 0, 0, time.UTC)
+	}
+	activity := []*reportActivity{
+		{Type: "comment", Time: at(12), Author: "Alice Smith", user: reportAlice, Message: "noon"},
+		{Type: "change", Time: at(9), Author: "Alice Smith", user: reportAlice, Message: "nine"},
+		{Type: "worklog", Time: at(10), Author: "Bob", user: reportBob, Message: "ten"},
+		{Type: "change", Time: at(7), Author: "Bob", user: reportBob, Message: "before the window"},
+		{Type: "change", Time: at(20), Author: "Alice Smith", user: reportAlice, Message: "after the window"},
+		{Type: "change", Time: at(11), Author: "Anonymous", Message: "automation"},
+	}
+	since, until := at(8), at(18)
+
+	summary := func(byAuthor map[string][]*reportActivity) map[string][]string {
+		messages := map[string][]string{}
+		for name, activity := range byAuthor {
+			for _, act := range activity {
+				messages[name] = append(messages[name], act.Message)
+			}
+		}
+		return messages
+	}
+
+	assert.Equal(t, map[string][]string{
+		"Alice Smith": {"nine", "noon"},
+		"Bob":         {"ten"},
+		"Anonymous":   {"automation"},
+	}, summary(reportByAuthor(activity, since, until, "")))
+
+	for _, author := range []string{"alice", "ALICE@example.com", "u1", "Alice Smith"} {
+		assert.Equal(t, map[string][]string{"Alice Smith": {"nine", "noon"}}, summary(reportByAuthor(activity, since, until, author)), author)
+	}
+	assert.Equal(t, map[string][]string{"Bob": {"ten"}}, summary(reportByAuthor(activity, since, until, "u2")))
+	assert.Empty(t, reportByAuthor(activity, since, until, "carol"))
+	// the window is inclusive
+	assert.Equal(t, map[string][]string{"Alice Smith": {"nine"}}, summary(reportByAuthor(activity, at(9), at(9), "")))
+}
+
+func TestReportUserMatch(t *testing.T) {
+	for user, expected := range map[string]bool{
+		"alice":             true,
+		"Alice":             true,
+		"u1":                true,
+		"alice@example.com": true,
+		"alice smith":       true,
+		"Alice S":           false,
+		"":                  false,
+	} {
+		assert.Equal(t, expected, reportUserMatch(user, reportAlice), user)
+	}
+	assert.False(t, reportUserMatch("alice", nil))
+}
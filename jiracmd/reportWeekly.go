@@ -0,0 +1,214 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ReportWeeklyOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string        `yaml:"project,omitempty" json:"project,omitempty"`
+	Query                 string        `yaml:"query,omitempty" json:"query,omitempty"`
+	Since                 time.Duration `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+// reportWeeklyData is sent to the "report-weekly" template, with the issues
+// grouped by epic.
+type reportWeeklyData struct {
+	Since time.Time     `json:"since" yaml:"since"`
+	Until time.Time     `json:"until" yaml:"until"`
+	Query string        `json:"query" yaml:"query"`
+	Epics []*reportEpic `json:"epics" yaml:"epics"`
+}
+
+type reportEpic struct {
+	// Key is empty for the issues that are not in an epic
+	Key        string         `json:"key" yaml:"key"`
+	Summary    string         `json:"summary" yaml:"summary"`
+	URL        string         `json:"url,omitempty" yaml:"url,omitempty"`
+	Resolved   []*reportIssue `json:"resolved" yaml:"resolved"`
+	InProgress []*reportIssue `json:"inProgress" yaml:"inProgress"`
+	Blocked    []*reportIssue `json:"blocked" yaml:"blocked"`
+}
+
+func CmdReportWeeklyRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReportWeeklyOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("report-weekly"),
+		},
+		Since: 7 * 24 * time.Hour,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Summarise the resolved, in progress and blocked issues by epic",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdReportWeeklyUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdReportWeekly(o, globals, &opts)
+		},
+	}
+}
+
+func CmdReportWeeklyUsage(cmd *kingpin.CmdClause, opts *ReportWeeklyOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "project to report on").Short('p').StringVar(&opts.Project)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to report on").Short('q').StringVar(&opts.Query)
+	cmd.Flag("since", "How far back to look for resolved issues").DurationVar(&opts.Since)
+	return nil
+}
+
+// CmdReportWeekly will sort the issues into resolved, in progress and
+// blocked, then group them by epic for the template.
+func CmdReportWeekly(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ReportWeeklyOptions) error {
+	until := time.Now()
+	minutes := reportMinutes(opts.Since)
+	data := &reportWeeklyData{
+		Since: until.Add(-opts.Since),
+		Until: until,
+		Query: reportQuery(opts.Query, opts.Project, fmt.Sprintf("(resolved >= -%dm OR (resolution = unresolved AND updated >= -%dm)) ORDER BY key", minutes, minutes)),
+	}
+
+	queryFields := []string{"status", "assignee", "resolution", "resolutiondate", "issuelinks", "issuetype", "parent"}
+	epicLink, flagged := "", ""
+	fields, err := jira.GetFields(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		switch field.Name {
+		case "Epic Link":
+			epicLink = field.ID
+			queryFields = append(queryFields, field.ID)
+		case "Flagged":
+			flagged = field.ID
+			queryFields = append(queryFields, field.ID)
+		}
+	}
+
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:       data.Query,
+		QueryFields: strings.Join(queryFields, ","),
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+
+	epics := map[string]*reportEpic{}
+	epicFor := func(key string) *reportEpic {
+		if _, ok := epics[key]; !ok {
+			epics[key] = &reportEpic{Key: key, Resolved: []*reportIssue{}, InProgress: []*reportIssue{}, Blocked: []*reportIssue{}}
+			if key != "" {
+				epics[key].URL = jira.URLJoin(globals.Endpoint.Value, "browse", key)
+			}
+		}
+		return epics[key]
+	}
+	for _, issue := range results.Issues {
+		entry := reportNewIssue(globals, issue)
+		epic := epicFor(issueEpicKey(issue, epicLink))
+		switch {
+		case issue.Fields["resolution"] != nil:
+			if reportTime(fmt.Sprint(issue.Fields["resolutiondate"])).Before(data.Since) {
+				// a custom query could find issues resolved before the window
+				continue
+			}
+			epic.Resolved = append(epic.Resolved, entry)
+		case reportBlocked(issue, flagged, entry):
+			epic.Blocked = append(epic.Blocked, entry)
+		case reportStatusCategory(issue) == "indeterminate":
+			epic.InProgress = append(epic.InProgress, entry)
+		}
+		if parent, ok := issue.Fields["parent"].(map[string]interface{}); ok && parent["key"] == epic.Key {
+			if parentFields, ok := parent["fields"].(map[string]interface{}); ok {
+				epic.Summary, _ = parentFields["summary"].(string)
+			}
+		}
+	}
+
+	// the Epic Link only has the key, so look up the summaries
+	missing := []string{}
+	for key, epic := range epics {
+		if key != "" && epic.Summary == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		found, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query: fmt.Sprintf("key in (%s)", strings.Join(missing, ",")),
+		}, jira.WithAutoPagination())
+		if err != nil {
+			return err
+		}
+		for _, issue := range found.Issues {
+			if epic, ok := epics[issue.Key]; ok {
+				epic.Summary, _ = issue.Fields["summary"].(string)
+			}
+		}
+	}
+
+	data.Epics = []*reportEpic{}
+	for _, epic := range epics {
+		if len(epic.Resolved)+len(epic.InProgress)+len(epic.Blocked) > 0 {
+			data.Epics = append(data.Epics, epic)
+		}
+	}
+	// issues without an epic go last
+	sort.Slice(data.Epics, func(i, j int) bool {
+		if data.Epics[i].Key == "" || data.Epics[j].Key == "" {
+			return data.Epics[j].Key == ""
+		}
+		return data.Epics[i].Key < data.Epics[j].Key
+	})
+
+	return opts.PrintTemplate(data)
+}
+
+// reportBlocked returns true when the unresolved issue is flagged, has a
+// blocked status or is blocked by another unresolved issue.  The blocking
+// issues are added to the entry.
+func reportBlocked(issue *jiradata.Issue, flagged string, entry *reportIssue) bool {
+	links, _ := issue.Fields["issuelinks"].([]interface{})
+	for _, data := range links {
+		link, _ := data.(map[string]interface{})
+		linkType, _ := link["type"].(map[string]interface{})
+		// this issue "is blocked by" the inward issue of a Blocks link
+		inward, ok := link["inwardIssue"].(map[string]interface{})
+		if !ok || linkType == nil || !strings.EqualFold(fmt.Sprint(linkType["name"]), "Blocks") {
+			continue
+		}
+		blocker := &jiradata.Issue{}
+		if err := jiracli.ConvertType(inward, blocker); err == nil && reportStatusCategory(blocker) != "done" {
+			entry.BlockedBy = append(entry.BlockedBy, blocker.Key)
+		}
+	}
+	if len(entry.BlockedBy) > 0 {
+		return true
+	}
+	if values, ok := issue.Fields[flagged].([]interface{}); ok && flagged != "" && len(values) > 0 {
+		return true
+	}
+	return strings.Contains(strings.ToLower(entry.Status), "blocked")
+}
+
+func reportStatusCategory(issue *jiradata.Issue) string {
+	if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+		if category, ok := status["statusCategory"].(map[string]interface{}); ok {
+			key, _ := category["key"].(string)
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportBlocked(t *testing.T) {
+	status := func(name, category string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "statusCategory": map[string]interface{}{"key": category}}
+	}
+	link := func(linkType, direction, key, category string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": map[string]interface{}{"name": linkType},
+			direction: map[string]interface{}{
+				"key":    key,
+				"fields": map[string]interface{}{"status": status("", category)},
+			},
+		}
+	}
+
+	for name, test := range map[string]struct {
+		fields    map[string]interface{}
+		blocked   bool
+		blockedBy []string
+	}{
+		"not blocked": {
+			fields: map[string]interface{}{"status": status("In Progress", "indeterminate")},
+		},
+		"flagged": {
+			fields:  map[string]interface{}{"status": status("In Progress", "indeterminate"), "customfield_10021": []interface{}{map[string]interface{}{"value": "Impediment"}}},
+			blocked: true,
+		},
+		"flag cleared": {
+			fields: map[string]interface{}{"status": status("In Progress", "indeterminate"), "customfield_10021": []interface{}{}},
+		},
+		"blocked status": {
+			fields:  map[string]interface{}{"status": status("Blocked - Waiting", "indeterminate")},
+			blocked: true,
+		},
+		"blocked by an open issue": {
+			fields: map[string]interface{}{
+				"status": status("In Progress", "indeterminate"),
+				"issuelinks": []interface{}{
+					link("Blocks", "inwardIssue", "TEST-2", "new"),
+					link("blocks", "inwardIssue", "TEST-3", "indeterminate"),
+				},
+			},
+			blocked:   true,
+			blockedBy: []string{"TEST-2", "TEST-3"},
+		},
+		"blocked by a done issue": {
+			fields: map[string]interface{}{
+				"status":     status("In Progress", "indeterminate"),
+				"issuelinks": []interface{}{link("Blocks", "inwardIssue", "TEST-2", "done")},
+			},
+		},
+		"blocking another issue": {
+			fields: map[string]interface{}{
+				"status":     status("In Progress", "indeterminate"),
+				"issuelinks": []interface{}{link("Blocks", "outwardIssue", "TEST-2", "new")},
+			},
+		},
+		"other link type": {
+			fields: map[string]interface{}{
+				"status":     status("In Progress", "indeterminate"),
+				"issuelinks": []interface{}{link("Relates", "inwardIssue", "TEST-2", "new")},
+			},
+		},
+	} {
+		issue := &jiradata.Issue{Key: "TEST-1", Fields: test.fields}
+		entry := &reportIssue{Key: issue.Key, Status: test.fields["status"].(map[string]interface{})["name"].(string)}
+		assert.Equal(t, test.blocked, reportBlocked(issue, "customfield_10021", entry), name)
+		assert.Equal(t, test.blockedBy, entry.BlockedBy, name)
+	}
+
+	// without a Flagged field only the status and links are used
+	issue := &jiradata.Issue{Key: "TEST-1", Fields: map[string]interface{}{"": []interface{}{"x"}}}
+	assert.False(t, reportBlocked(issue, "", &reportIssue{Key: "TEST-1"}))
+}
+
+func TestReportStatusCategory(t *testing.T) {
+	issue := &jiradata.Issue{Fields: map[string]interface{}{
+		"status": map[string]interface{}{"name": "Done", "statusCategory": map[string]interface{}{"key": "done"}},
+	}}
+	assert.Equal(t, "done", reportStatusCategory(issue))
+	assert.Equal(t, "", reportStatusCategory(&jiradata.Issue{Fields: map[string]interface{}{}}))
+}
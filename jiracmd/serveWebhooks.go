@@ -0,0 +1,337 @@
+package jiracmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	shellquote "github.com/kballard/go-shellquote"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ServeWebhooksOptions struct {
+	Listen   string           `yaml:"listen,omitempty" json:"listen,omitempty"`
+	Path     string           `yaml:"webhook-path,omitempty" json:"webhook-path,omitempty"`
+	Secret   string           `yaml:"webhook-secret,omitempty" json:"webhook-secret,omitempty"`
+	Handlers []WebhookHandler `yaml:"webhook-handlers,omitempty" json:"webhook-handlers,omitempty"`
+}
+
+// WebhookHandler is run for the webhook events that match the Events and
+// Filter.  Command is a jira command line (usually one of your
+// custom-commands) where each argument is a template, and Template is the name
+// of a template to print, at least one should be set.
+type WebhookHandler struct {
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Events are the webhookEvent (ie "jira:issue_created") or
+	// issue_event_type_name (ie "issue_commented") values to handle, they can
+	// use glob patterns like "jira:issue_*".  All events are handled when
+	// this is empty.
+	Events []string `yaml:"events,omitempty" json:"events,omitempty"`
+	// Filter is a JQL expression the issue in the event must match, this is
+	// evaluated locally so only the JQL supported offline can be used.
+	Filter   string `yaml:"filter,omitempty" json:"filter,omitempty"`
+	Command  string `yaml:"command,omitempty" json:"command,omitempty"`
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+
+	filter *jira.JQLFilter
+}
+
+// WebhookEvent is the payload Jira sends for a webhook
+type WebhookEvent struct {
+	Timestamp          int64                   `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	WebhookEvent       string                  `json:"webhookEvent,omitempty" yaml:"webhookEvent,omitempty"`
+	IssueEventTypeName string                  `json:"issue_event_type_name,omitempty" yaml:"issue_event_type_name,omitempty"`
+	User               *jiradata.User          `json:"user,omitempty" yaml:"user,omitempty"`
+	Issue              *jiradata.Issue         `json:"issue,omitempty" yaml:"issue,omitempty"`
+	Changelog          *jiradata.ChangeHistory `json:"changelog,omitempty" yaml:"changelog,omitempty"`
+	Comment            *jiradata.Comment       `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Worklog            *jiradata.Worklog       `json:"worklog,omitempty" yaml:"worklog,omitempty"`
+}
+
+func CmdServeWebhooksRegistry() *jiracli.CommandRegistryEntry {
+	opts := ServeWebhooksOptions{
+		Listen: "127.0.0.1:8080",
+		Path:   "/",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Receive Jira webhooks and run the configured webhook-handlers",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdServeWebhooksUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdServeWebhooks(o, globals, &opts)
+		},
+	}
+}
+
+func CmdServeWebhooksUsage(cmd *kingpin.CmdClause, opts *ServeWebhooksOptions) error {
+	cmd.Flag("listen", "Address to listen on for webhooks").Short('l').StringVar(&opts.Listen)
+	cmd.Flag("path", "URL path to accept webhooks on").StringVar(&opts.Path)
+	cmd.Flag("secret", "Secret used to verify the webhooks, checked against the X-Hub-Signature header or the token query parameter").StringVar(&opts.Secret)
+	return nil
+}
+
+// CmdServeWebhooks will listen for Jira webhooks and dispatch each event to
+// the matching webhook-handlers.  Handlers are run one at a time in the order
+// the events arrive so they do not race each other updating the same issue.
+func CmdServeWebhooks(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ServeWebhooksOptions) error {
+	if len(opts.Handlers) == 0 {
+		return jiracli.CliError(fmt.Errorf("No webhook-handlers configured"))
+	}
+	// the handlers run commands as the user, so anything that can reach the
+	// server must prove it knows the secret
+	if opts.Secret == "" && !webhookLoopback(opts.Listen) {
+		return jiracli.CliError(fmt.Errorf("A webhook-secret is required to listen on %s, only loopback addresses can be used without one", opts.Listen))
+	}
+
+	// fields are only needed to find custom fields by name in the filters
+	var fields []jiradata.Field
+	for _, handler := range opts.Handlers {
+		if handler.Filter != "" {
+			if f, err := jira.GetFields(jiracli.CacheClient(o, globals), globals.Endpoint.Value); err != nil {
+				log.Warningf("Unable to fetch fields, filters can only use field ids: %s", err)
+			} else {
+				fields = f
+			}
+			break
+		}
+	}
+	for i := range opts.Handlers {
+		handler := &opts.Handlers[i]
+		if handler.Name == "" {
+			handler.Name = fmt.Sprintf("handler %d", i+1)
+		}
+		if handler.Command == "" && handler.Template == "" {
+			return jiracli.CliError(fmt.Errorf("webhook-handler %q needs a command or template", handler.Name))
+		}
+		if handler.Filter != "" {
+			filter, err := jira.NewJQLFilter(handler.Filter, fields)
+			if err != nil {
+				return jiracli.CliError(fmt.Errorf("Invalid filter for webhook-handler %q: %s", handler.Name, err))
+			}
+			handler.filter = filter
+		}
+	}
+
+	events := make(chan *WebhookEvent, 100)
+	go func() {
+		for event := range events {
+			for i := range opts.Handlers {
+				if err := runWebhookHandler(&opts.Handlers[i], event); err != nil {
+					log.Errorf("%s failed for %s: %s", opts.Handlers[i].Name, webhookDescribe(event), err)
+				}
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(opts.Path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !webhookAuthorized(opts.Secret, r, body) {
+			log.Warningf("Rejected webhook from %s with an invalid signature", r.RemoteAddr)
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		event := &WebhookEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			http.Error(w, fmt.Sprintf("invalid webhook: %s", err), http.StatusBadRequest)
+			return
+		}
+		log.Debugf("Received %s", webhookDescribe(event))
+		select {
+		case events <- event:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "too many webhooks queued", http.StatusServiceUnavailable)
+		}
+	})
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK listening for webhooks on %s%s\n", opts.Listen, opts.Path)
+	}
+	return http.ListenAndServe(opts.Listen, mux)
+}
+
+// webhookLoopback returns true when the listen address only accepts
+// connections from this machine.
+func webhookLoopback(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// webhookAuthorized checks the HMAC signature Jira sends when the webhook
+// has a secret, or a token query parameter for webhooks that cannot sign.
+// Without a secret the webhooks are only accepted on a loopback address.
+func webhookAuthorized(secret string, r *http.Request, body []byte) bool {
+	if secret == "" {
+		return true
+	}
+	if signature := r.Header.Get("X-Hub-Signature"); signature != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(signature), []byte(expected))
+	}
+	return hmac.Equal([]byte(r.URL.Query().Get("token")), []byte(secret))
+}
+
+func webhookDescribe(event *WebhookEvent) string {
+	name := event.WebhookEvent
+	if event.IssueEventTypeName != "" {
+		name = fmt.Sprintf("%s (%s)", name, event.IssueEventTypeName)
+	}
+	if event.Issue != nil {
+		name = fmt.Sprintf("%s for %s", name, event.Issue.Key)
+	}
+	return name
+}
+
+func (h *WebhookHandler) matches(event *WebhookEvent) (bool, error) {
+	if len(h.Events) > 0 {
+		found := false
+		for _, pattern := range h.Events {
+			for _, name := range []string{event.WebhookEvent, event.IssueEventTypeName} {
+				if ok, _ := path.Match(pattern, name); ok && name != "" {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if h.filter != nil {
+		if event.Issue == nil {
+			return false, nil
+		}
+		return h.filter.Match(event.Issue)
+	}
+	return true, nil
+}
+
+func runWebhookHandler(handler *WebhookHandler, event *WebhookEvent) error {
+	if ok, err := handler.matches(event); err != nil || !ok {
+		return err
+	}
+	log.Noticef("Running %s for %s", handler.Name, webhookDescribe(event))
+
+	if handler.Template != "" {
+		if err := jiracli.RunTemplate(handler.Template, event, nil); err != nil {
+			return err
+		}
+	}
+	if handler.Command == "" {
+		return nil
+	}
+
+	// the templates see the same data as the other templates, so convert
+	// the event to the generic json types
+	var data interface{}
+	if err := jiracli.ConvertType(event, &data); err != nil {
+		return err
+	}
+	args, err := webhookCommandArgs(handler.Command, data)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		binary = os.Args[0]
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Running: %s %s", binary, shellquote.Join(args...))
+	cmd := exec.Command(binary, args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), "JIRA_WEBHOOK_EVENT="+event.WebhookEvent, "JIRA_ISSUE_EVENT="+event.IssueEventTypeName)
+	if event.Issue != nil {
+		cmd.Env = append(cmd.Env, "JIRA_ISSUE="+event.Issue.Key)
+	}
+	return cmd.Run()
+}
+
+var (
+	webhookAction      = regexp.MustCompile(`(?s){{.*?}}`)
+	webhookPlaceholder = regexp.MustCompile("\x00([0-9]+)\x00")
+)
+
+// webhookCommandArgs splits the handler command into arguments before the
+// templates are run, so each template fills in exactly one argument and the
+// event data (summaries, comments, labels) cannot add arguments of its own.
+func webhookCommandArgs(command string, data interface{}) ([]string, error) {
+	// the template actions can have spaces and quotes, so hide them from
+	// the split and put them back in the word they were found in
+	actions := webhookAction.FindAllString(command, -1)
+	i := 0
+	words, err := shellquote.Split(webhookAction.ReplaceAllStringFunc(command, func(string) string {
+		i++
+		return fmt.Sprintf("\x00%d\x00", i-1)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, 0, len(words))
+	for _, word := range words {
+		word = webhookPlaceholder.ReplaceAllStringFunc(word, func(match string) string {
+			n, _ := strconv.Atoi(strings.Trim(match, "\x00"))
+			return actions[n]
+		})
+		tmpl, err := jiracli.TemplateProcessor().Parse(word)
+		if err != nil {
+			return nil, err
+		}
+		buf := bytes.NewBufferString("")
+		if err := tmpl.Execute(buf, data); err != nil {
+			return nil, err
+		}
+		arg := buf.String()
+		// a value must not turn into a flag, or an @file that kingpin
+		// would read more arguments from
+		if arg != "" && strings.ContainsAny(arg[:1], "-@") && !strings.HasPrefix(word, arg[:1]) {
+			return nil, fmt.Errorf("The event data in %q starts with %q, read it from stdin instead", word, arg[:1])
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
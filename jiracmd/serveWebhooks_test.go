@@ -0,0 +1,94 @@
+package jiracmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookAuthorized(t *testing.T) {
+	body := []byte(`{"webhookEvent":"jira:issue_created"}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	for name, test := range map[string]struct {
+		secret    string
+		signature string
+		target    string
+		expected  bool
+	}{
+		"valid signature":      {"s3cret", signature, "/", true},
+		"bad signature":        {"s3cret", "sha256=0123", "/", false},
+		"other secret":         {"other", signature, "/", false},
+		"missing header":       {"s3cret", "", "/", false},
+		"valid token":          {"s3cret", "", "/?token=s3cret", true},
+		"bad token":            {"s3cret", "", "/?token=guess", false},
+		"signature over token": {"s3cret", "sha256=0123", "/?token=s3cret", false},
+		"no secret":            {"", "", "/", true},
+	} {
+		r := httptest.NewRequest("POST", test.target, nil)
+		if test.signature != "" {
+			r.Header.Set("X-Hub-Signature", test.signature)
+		}
+		assert.Equal(t, test.expected, webhookAuthorized(test.secret, r, body), name)
+	}
+}
+
+func TestWebhookLoopback(t *testing.T) {
+	for listen, expected := range map[string]bool{
+		"127.0.0.1:8080":  true,
+		"localhost:8080":  true,
+		"[::1]:8080":      true,
+		":8080":           false,
+		"0.0.0.0:8080":    false,
+		"10.1.2.3:8080":   false,
+		"jira.local:8080": false,
+		"8080":            false,
+	} {
+		assert.Equal(t, expected, webhookLoopback(listen), listen)
+	}
+}
+
+func TestWebhookCommandArgs(t *testing.T) {
+	data := map[string]interface{}{
+		"issue": map[string]interface{}{
+			"key": "TEST-1",
+			"fields": map[string]interface{}{
+				"summary": "it's broken",
+				"labels":  []interface{}{"a b", "c"},
+			},
+		},
+		"comment": map[string]interface{}{
+			"body": `" --endpoint https://evil -o x`,
+		},
+	}
+
+	for name, test := range map[string]struct {
+		command  string
+		expected []string
+	}{
+		"plain":          {"labels add {{ .issue.key }} urgent", []string{"labels", "add", "TEST-1", "urgent"}},
+		"hostile body":   {"comment {{ .issue.key }} --noedit -m {{ .comment.body }}", []string{"comment", "TEST-1", "--noedit", "-m", `" --endpoint https://evil -o x`}},
+		"quoted action":  {`comment {{ .issue.key }} -m "re: {{ .issue.fields.summary }}"`, []string{"comment", "TEST-1", "-m", "re: it's broken"}},
+		"flag value":     {"comment {{ .issue.key }} --message={{ .comment.body }}", []string{"comment", "TEST-1", `--message=" --endpoint https://evil -o x`}},
+		"quotes inside":  {`view {{ index .issue "key" }}`, []string{"view", "TEST-1"}},
+		"range in word":  {`labels add {{ .issue.key }} {{ range .issue.fields.labels }}{{ . }}{{ end }}`, []string{"labels", "add", "TEST-1", "a bc"}},
+		"empty template": {"", []string{}},
+	} {
+		args, err := webhookCommandArgs(test.command, data)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, test.expected, args, name)
+		}
+	}
+
+	for _, body := range []string{"--endpoint=https://evil", "-ohttps://evil", "@/etc/passwd"} {
+		data["comment"] = map[string]interface{}{"body": body}
+		_, err := webhookCommandArgs("comment {{ .issue.key }} {{ .comment.body }}", data)
+		assert.Error(t, err, body)
+	}
+}
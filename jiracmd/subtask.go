@@ -57,7 +57,7 @@ func CmdSubtaskUsage(cmd *kingpin.CmdClause, opts *SubtaskOptions) error {
 		return nil
 	}).String()
 	cmd.Flag("override", "Set issue property").Short('o').StringMapVar(&opts.Overrides)
-	cmd.Arg("ISSUE", "Parent issue for subtask").StringVar(&opts.Issue)
+	cmd.Arg("ISSUE", "Parent issue for subtask").HintAction(jiracli.IssueHint()).StringVar(&opts.Issue)
 	return nil
 }
 
@@ -65,7 +65,7 @@ func CmdSubtaskUsage(cmd *kingpin.CmdClause, opts *SubtaskOptions) error {
 // will parse the edited document as YAML and submit the document to jira.
 func CmdSubtask(o *oreo.Client, globals *jiracli.GlobalOptions, opts *SubtaskOptions) error {
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
@@ -93,7 +93,7 @@ func CmdSubtask(o *oreo.Client, globals *jiracli.GlobalOptions, opts *SubtaskOpt
 		return fmt.Errorf("Failed to find Project field in parent issue")
 	}
 
-	createMeta, err := jira.GetIssueCreateMetaIssueType(o, globals.Endpoint.Value, opts.Project, opts.IssueType)
+	createMeta, err := jira.GetIssueCreateMetaIssueType(jiracli.CacheClient(o, globals), globals.Endpoint.Value, opts.Project, opts.IssueType)
 	if err != nil {
 		return err
 	}
@@ -110,6 +110,12 @@ func CmdSubtask(o *oreo.Client, globals *jiracli.GlobalOptions, opts *SubtaskOpt
 
 	var issueResp *jiradata.IssueCreateResponse
 	err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, func() error {
+		if err := jiracli.ResolveIssueUpdate(&issueUpdate, createMeta.Fields, opts.Overrides); err != nil {
+			return err
+		}
+		if err := jiracli.CheckIssueUpdate(&issueUpdate, createMeta.Fields, "create"); err != nil {
+			return err
+		}
 		if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 			err := fixGDPRUserFields(o, globals.Endpoint.Value, createMeta.Fields, issueUpdate.Fields)
 			if err != nil {
@@ -0,0 +1,252 @@
+package jiracmd
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type SyncOptions struct {
+	Query   string `yaml:"query,omitempty" json:"query,omitempty"`
+	Push    bool   `yaml:"push,omitempty" json:"push,omitempty"`
+	Force   bool   `yaml:"force,omitempty" json:"force,omitempty"`
+	Discard bool   `yaml:"discard,omitempty" json:"discard,omitempty"`
+}
+
+func CmdSyncRegistry() *jiracli.CommandRegistryEntry {
+	opts := SyncOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Mirror issues for offline use, or push the changes made offline",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdSyncUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdSync(o, globals, &opts)
+		},
+	}
+}
+
+func CmdSyncUsage(cmd *kingpin.CmdClause, opts *SyncOptions) error {
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to mirror").Short('q').StringVar(&opts.Query)
+	cmd.Flag("push", "Push the changes made offline to Jira").BoolVar(&opts.Push)
+	cmd.Flag("force", "Push changes even if the issue was updated since it was synced").BoolVar(&opts.Force)
+	cmd.Flag("discard", "Discard changes to issues that were updated since they were synced").BoolVar(&opts.Discard)
+	return nil
+}
+
+// CmdSync will push any queued offline changes when requested, then mirror
+// the issues found with the query into the offline store.
+func CmdSync(o *oreo.Client, globals *jiracli.GlobalOptions, opts *SyncOptions) error {
+	store := jiracli.OfflineStore(globals)
+
+	refresh := []string{}
+	if opts.Push {
+		pushed, err := syncPush(o, globals, store, opts)
+		refresh = append(refresh, pushed...)
+		if err != nil {
+			if len(refresh) == 0 {
+				return err
+			}
+			// refresh what we managed to push before reporting the error
+			if err := syncIssues(o, globals, store, refresh); err != nil {
+				log.Errorf("%s", err)
+			}
+			return err
+		}
+		if opts.Query == "" {
+			if len(refresh) == 0 {
+				return nil
+			}
+			return syncIssues(o, globals, store, refresh)
+		}
+	}
+
+	query := opts.Query
+	if query == "" {
+		query = globals.DefaultQuery.Value
+	}
+	if query == "" {
+		query = jiracli.DefaultIssueQuery
+	}
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{Query: query}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+	for _, issue := range results.Issues {
+		refresh = append(refresh, issue.Key)
+	}
+
+	fields, err := jira.GetFields(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	if err := store.SaveFields(fields); err != nil {
+		return err
+	}
+	serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	if err := store.SaveServerInfo(serverInfo); err != nil {
+		return err
+	}
+	myself, err := jira.Myself(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	if err := store.SaveMyself(myself); err != nil {
+		return err
+	}
+
+	return syncIssues(o, globals, store, refresh)
+}
+
+// syncIssues will fetch the issues and the metadata needed to view, edit and
+// transition them offline.  Issues with changes waiting to be pushed are
+// skipped so the offline changes are not lost.
+func syncIssues(o *oreo.Client, globals *jiracli.GlobalOptions, store *jira.OfflineStore, keys []string) error {
+	queue, err := store.Queue()
+	if err != nil {
+		return err
+	}
+	pending := map[string]bool{}
+	for _, req := range queue {
+		pending[req.Issue] = true
+	}
+
+	seen := map[string]bool{}
+	count := 0
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if pending[key] {
+			log.Warningf("Skipping %s, it has changes waiting for `jira sync --push`", key)
+			continue
+		}
+
+		issue, err := jira.GetIssue(o, globals.Endpoint.Value, key, nil)
+		if err != nil {
+			return err
+		}
+		offline := &jira.OfflineIssue{
+			Issue:  issue,
+			Synced: time.Now(),
+		}
+		if offline.Transitions, err = jira.GetIssueTransitions(o, globals.Endpoint.Value, key); err != nil {
+			return err
+		}
+		if offline.EditMeta, err = jira.GetIssueEditMeta(o, globals.Endpoint.Value, key); err != nil {
+			return err
+		}
+		// like view, do not fail if remote links or watchers are unavailable
+		if links, err := jira.GetRemoteLinks(o, globals.Endpoint.Value, key); err != nil {
+			log.Debugf("Unable to fetch remote links for %s: %s", key, err)
+		} else {
+			offline.RemoteLinks = *links
+		}
+		if offline.Watchers, err = jira.GetIssueWatchers(o, globals.Endpoint.Value, key); err != nil {
+			log.Debugf("Unable to fetch watchers for %s: %s", key, err)
+		}
+		if err := store.SaveIssue(offline); err != nil {
+			return err
+		}
+		count++
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK synced %d issues to %s\n", count, store.Dir)
+	}
+	return nil
+}
+
+// syncPush will replay the queued offline changes in order.  If an issue has
+// been updated in Jira since it was synced the changes for that issue are a
+// conflict and are left in the queue, unless forced or discarded.  The keys
+// of the issues that were pushed or discarded are returned so they can be
+// refreshed, even when an error is returned.
+func syncPush(o *oreo.Client, globals *jiracli.GlobalOptions, store *jira.OfflineStore, opts *SyncOptions) ([]string, error) {
+	queue, err := store.Queue()
+	if err != nil {
+		return nil, err
+	}
+	if len(queue) == 0 {
+		if !globals.Quiet.Value {
+			fmt.Println("OK no offline changes to push")
+		}
+		return nil, nil
+	}
+
+	remaining := []*jira.OfflineRequest{}
+	checked := map[string]bool{}
+	conflicts := map[string]bool{}
+	discarded := map[string]bool{}
+	pushed := []string{}
+	for i, req := range queue {
+		if conflicts[req.Issue] {
+			remaining = append(remaining, req)
+			continue
+		}
+		if discarded[req.Issue] {
+			log.Warningf("Discarded %s for %s", syncDescribe(req), req.Issue)
+			continue
+		}
+		if !checked[req.Issue] && !opts.Force {
+			issue, err := jira.GetIssue(o, globals.Endpoint.Value, req.Issue, &jira.IssueOptions{Fields: []string{"updated"}})
+			if err != nil {
+				store.SaveQueue(append(remaining, queue[i:]...))
+				return pushed, jiracli.CliError(err)
+			}
+			if updated, _ := issue.Fields["updated"].(string); updated != req.Updated {
+				if opts.Discard {
+					log.Warningf("Discarded %s for %s, it was updated at %s", syncDescribe(req), req.Issue, updated)
+					discarded[req.Issue] = true
+					pushed = append(pushed, req.Issue)
+					continue
+				}
+				log.Errorf("Conflict for %s, it was updated at %s after it was synced at %s", req.Issue, updated, req.Updated)
+				conflicts[req.Issue] = true
+				remaining = append(remaining, req)
+				continue
+			}
+		}
+		checked[req.Issue] = true
+
+		if err := jira.ReplayOfflineRequest(o, req); err != nil {
+			store.SaveQueue(append(remaining, queue[i:]...))
+			return pushed, jiracli.CliError(fmt.Errorf("Failed to push %s for %s: %s", syncDescribe(req), req.Issue, err))
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK pushed %s for %s\n", syncDescribe(req), req.Issue)
+		}
+		pushed = append(pushed, req.Issue)
+	}
+
+	if err := store.SaveQueue(remaining); err != nil {
+		return pushed, err
+	}
+	if len(conflicts) > 0 {
+		return pushed, jiracli.CliError(fmt.Errorf("%d issues have conflicting changes, use --force to push them anyway or --discard to drop them", len(conflicts)))
+	}
+	return pushed, nil
+}
+
+func syncDescribe(req *jira.OfflineRequest) string {
+	if req.Method == "PUT" {
+		return "edit"
+	}
+	switch name := path.Base(req.URI); name {
+	case "transitions":
+		return "transition"
+	default:
+		return name
+	}
+}
@@ -0,0 +1,136 @@
+package jiracmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncPush(t *testing.T) {
+	const synced = "2020-01-02T10:00:00.000+0000"
+	updated := map[string]string{}
+	failing := ""
+	received := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			key := path.Base(r.URL.Path)
+			received = append(received, "GET "+key)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"key":    key,
+				"fields": map[string]interface{}{"updated": updated[key]},
+			})
+			return
+		}
+		received = append(received, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/"))
+		if r.URL.Path == failing {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"errorMessages":["Transition is not valid"]}`))
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+
+	queued := func(key, method, uri string) *jira.OfflineRequest {
+		return &jira.OfflineRequest{
+			Issue:   key,
+			Method:  method,
+			URI:     server.URL + "/rest/api/2/issue/" + uri,
+			Body:    json.RawMessage(`{}`),
+			Updated: synced,
+		}
+	}
+	queue := []*jira.OfflineRequest{
+		queued("TEST-1", "POST", "TEST-1/comment"),
+		queued("TEST-2", "PUT", "TEST-2"),
+		queued("TEST-1", "POST", "TEST-1/transitions"),
+		queued("TEST-2", "POST", "TEST-2/comment"),
+	}
+
+	for name, test := range map[string]struct {
+		opts      SyncOptions
+		updated   map[string]string
+		failing   string
+		received  []string
+		pushed    []string
+		remaining []string
+		error     string
+	}{
+		"no conflicts": {
+			updated:  map[string]string{"TEST-1": synced, "TEST-2": synced},
+			received: []string{"GET TEST-1", "POST TEST-1/comment", "GET TEST-2", "PUT TEST-2", "POST TEST-1/transitions", "POST TEST-2/comment"},
+			pushed:   []string{"TEST-1", "TEST-2", "TEST-1", "TEST-2"},
+		},
+		"conflict": {
+			updated:   map[string]string{"TEST-1": synced, "TEST-2": "2020-01-03T09:00:00.000+0000"},
+			received:  []string{"GET TEST-1", "POST TEST-1/comment", "GET TEST-2", "POST TEST-1/transitions"},
+			pushed:    []string{"TEST-1", "TEST-1"},
+			remaining: []string{"PUT TEST-2", "POST TEST-2/comment"},
+			error:     "1 issues have conflicting changes, use --force to push them anyway or --discard to drop them",
+		},
+		"force": {
+			opts:     SyncOptions{Force: true},
+			updated:  map[string]string{"TEST-1": synced, "TEST-2": "2020-01-03T09:00:00.000+0000"},
+			received: []string{"POST TEST-1/comment", "PUT TEST-2", "POST TEST-1/transitions", "POST TEST-2/comment"},
+			pushed:   []string{"TEST-1", "TEST-2", "TEST-1", "TEST-2"},
+		},
+		"discard": {
+			opts:     SyncOptions{Discard: true},
+			updated:  map[string]string{"TEST-1": synced, "TEST-2": "2020-01-03T09:00:00.000+0000"},
+			received: []string{"GET TEST-1", "POST TEST-1/comment", "GET TEST-2", "POST TEST-1/transitions"},
+			pushed:   []string{"TEST-1", "TEST-2", "TEST-1"},
+		},
+		"failed push": {
+			updated:   map[string]string{"TEST-1": synced, "TEST-2": synced},
+			failing:   "/rest/api/2/issue/TEST-2",
+			received:  []string{"GET TEST-1", "POST TEST-1/comment", "GET TEST-2", "PUT TEST-2"},
+			pushed:    []string{"TEST-1"},
+			remaining: []string{"PUT TEST-2", "POST TEST-1/transitions", "POST TEST-2/comment"},
+			error:     "Failed to push edit for TEST-2: Transition is not valid",
+		},
+	} {
+		dir, err := ioutil.TempDir("", "jira-sync")
+		assert.NoError(t, err)
+		store := &jira.OfflineStore{Dir: dir}
+		assert.NoError(t, store.SaveQueue(queue))
+		updated, failing, received = test.updated, test.failing, nil
+
+		globals := &jiracli.GlobalOptions{
+			Endpoint: figtree.NewStringOption(server.URL),
+			Quiet:    figtree.NewBoolOption(true),
+		}
+		pushed, err := syncPush(oreo.New().WithRetries(0), globals, store, &test.opts)
+		if test.error == "" {
+			assert.NoError(t, err, name)
+		} else if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), test.error, name)
+		}
+		assert.Equal(t, test.received, received, name)
+		assert.Equal(t, test.pushed, pushed, name)
+
+		remaining, err := store.Queue()
+		assert.NoError(t, err, name)
+		descriptions := []string{}
+		for _, req := range remaining {
+			descriptions = append(descriptions, fmt.Sprintf("%s %s", req.Method, strings.TrimPrefix(req.URI, server.URL+"/rest/api/2/issue/")))
+		}
+		if test.remaining == nil {
+			test.remaining = []string{}
+		}
+		assert.Equal(t, test.remaining, descriptions, name)
+		os.RemoveAll(dir)
+	}
+}
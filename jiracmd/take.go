@@ -28,6 +28,6 @@ func CmdTakeRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdTakeUsage(cmd *kingpin.CmdClause, opts *AssignOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue to assign").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to assign", &opts.Issue)
 	return nil
 }
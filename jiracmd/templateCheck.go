@@ -0,0 +1,75 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TemplateCheckOptions struct {
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+func CmdTemplateCheckRegistry() *jiracli.CommandRegistryEntry {
+	opts := TemplateCheckOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Check templates for parse errors, unknown functions and missing keys",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTemplateCheckUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdTemplateCheck(globals, &opts)
+		},
+	}
+}
+
+func CmdTemplateCheckUsage(cmd *kingpin.CmdClause, opts *TemplateCheckOptions) error {
+	cmd.Arg("NAME", "Template to check, all templates are checked by default").StringVar(&opts.Template)
+	return nil
+}
+
+// CmdTemplateCheck will parse the templates and run them with sample data
+// for the commands that use them.
+func CmdTemplateCheck(globals *jiracli.GlobalOptions, opts *TemplateCheckOptions) error {
+	names := []string{opts.Template}
+	if opts.Template == "" {
+		var err error
+		if names, err = jiracli.TemplateNames(); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, name := range names {
+		problem, err := jiracli.CheckTemplate(name)
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		switch {
+		case problem == nil:
+			if !globals.Quiet.Value {
+				if _, ok := jiracli.TemplateSample(name); ok {
+					fmt.Printf("OK %s\n", name)
+				} else {
+					fmt.Printf("OK %s (parsed only, no sample data)\n", name)
+				}
+			}
+		case problem.Kind == "missing-key":
+			// templates can refer to fields that are not in the sample data,
+			// like custom fields, so only warn about these
+			log.Warningf("%s: %s", name, problem)
+		default:
+			log.Errorf("%s: %s", name, problem)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return jiracli.CliError(fmt.Errorf("%d of %d templates failed the check", failed, len(names)))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	yaml "gopkg.in/coryb/yaml.v2"
+)
+
+type TemplateRenderOptions struct {
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+	Data     string `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func CmdTemplateRenderRegistry() *jiracli.CommandRegistryEntry {
+	opts := TemplateRenderOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Render a template with data from a file to preview the output",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTemplateRenderUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdTemplateRender(&opts)
+		},
+	}
+}
+
+func CmdTemplateRenderUsage(cmd *kingpin.CmdClause, opts *TemplateRenderOptions) error {
+	cmd.Flag("data", "JSON or YAML file with the template data, or - for stdin, the sample data for the template is used by default").Short('d').StringVar(&opts.Data)
+	cmd.Arg("NAME", "Template to render").Required().StringVar(&opts.Template)
+	return nil
+}
+
+// CmdTemplateRender will run the template with the data, the data can be
+// captured from a command with `-t json`.
+func CmdTemplateRender(opts *TemplateRenderOptions) error {
+	var data interface{}
+	switch opts.Data {
+	case "":
+		sample, ok := jiracli.TemplateSample(opts.Template)
+		if !ok {
+			return jiracli.CliError(fmt.Errorf("There is no sample data for the %q template, use --data", opts.Template))
+		}
+		data = sample
+	default:
+		var content []byte
+		var err error
+		if opts.Data == "-" {
+			content, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			content, err = ioutil.ReadFile(opts.Data)
+		}
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		// yaml will also parse json
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return jiracli.CliError(fmt.Errorf("Unable to parse %s: %s", opts.Data, err))
+		}
+	}
+	return jiracli.RunTemplate(opts.Template, data, nil)
+}
@@ -62,9 +62,9 @@ func CmdTransitionUsage(cmd *kingpin.CmdClause, opts *TransitionOptions) error {
 	}).String()
 	cmd.Flag("override", "Set issue property").Short('o').StringMapVar(&opts.Overrides)
 	if opts.Transition == "" {
-		cmd.Arg("TRANSITION", "State to transition issue to").Required().StringVar(&opts.Transition)
+		cmd.Arg("TRANSITION", "State to transition issue to").HintAction(transitionHint()).Required().StringVar(&opts.Transition)
 	}
-	cmd.Arg("ISSUE", "issue to transition").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to transition", &opts.Issue)
 	cmd.Flag("resolution", "Set resolution on transition").StringVar(&opts.Resolution)
 	return nil
 }
@@ -86,8 +86,9 @@ func defaultResolution(transMeta *jiradata.Transition) string {
 
 // CmdTransition will move state of the given issue to the given transtion
 func CmdTransition(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TransitionOptions) error {
+	cache := jiracli.CacheClient(o, globals)
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(cache, globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
@@ -99,12 +100,22 @@ func CmdTransition(o *oreo.Client, globals *jiracli.GlobalOptions, opts *Transit
 		return jiracli.CliError(err)
 	}
 
-	meta, err := jira.GetIssueTransitions(o, globals.Endpoint.Value, opts.Issue)
+	meta, err := jira.GetIssueTransitions(cache, globals.Endpoint.Value, opts.Issue)
 	if err != nil {
 		return jiracli.CliError(err)
 	}
 	transMeta := meta.Transitions.Find(opts.Transition)
 
+	if transMeta == nil {
+		// the cached transitions could be stale if the issue was modified
+		// elsewhere, so check with the server before giving up
+		meta, err = jira.GetIssueTransitions(o, globals.Endpoint.Value, opts.Issue)
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		transMeta = meta.Transitions.Find(opts.Transition)
+	}
+
 	if transMeta == nil {
 		possible := []string{}
 		for _, trans := range meta.Transitions {
@@ -159,6 +170,10 @@ func CmdTransition(o *oreo.Client, globals *jiracli.GlobalOptions, opts *Transit
 		Overrides:  opts.Overrides,
 	}
 	err = jiracli.EditLoop(&opts.CommonOptions, &input, &issueUpdate, func() error {
+		// map any field names to their ids
+		if err := jiracli.ResolveIssueUpdate(&issueUpdate, transMeta.Fields, opts.Overrides); err != nil {
+			return err
+		}
 		if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
 			err := fixGDPRUserFields(o, globals.Endpoint.Value, transMeta.Fields, issueUpdate.Fields)
 			if err != nil {
@@ -166,26 +181,7 @@ func CmdTransition(o *oreo.Client, globals *jiracli.GlobalOptions, opts *Transit
 			}
 		}
 
-		// if issueUpdate contains fields lets see if we can map them
-		// to their ids
-		if len(issueUpdate.Fields) > 0 {
-			fields, err := jira.GetFields(o, globals.Endpoint.Value)
-			if err != nil {
-				return err
-			}
-			for k, v := range issueUpdate.Fields {
-				for _, f := range fields {
-					if f.Name == k {
-						// re-map to field.id
-						issueUpdate.Fields[f.ID] = v
-						delete(issueUpdate.Fields, k)
-						break
-					}
-				}
-			}
-		}
-
-		return jira.TransitionIssue(o, globals.Endpoint.Value, opts.Issue, &issueUpdate)
+		return jira.TransitionIssue(cache, globals.Endpoint.Value, opts.Issue, &issueUpdate)
 	})
 	if err != nil {
 		return jiracli.CliError(err)
@@ -38,7 +38,7 @@ func CmdTransitionsUsage(cmd *kingpin.CmdClause, opts *TransitionsOptions) error
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue to list valid transitions").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to list valid transitions", &opts.Issue)
 	return nil
 }
 
@@ -0,0 +1,364 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TreeOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string   `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Depth                 int      `yaml:"depth,omitempty" json:"depth,omitempty"`
+	Links                 []string `yaml:"links,omitempty" json:"links,omitempty"`
+	Output                string   `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// treeData is sent to the "tree" templates, Rows are the issues in the
+// order they are printed and Nodes are the unique issues for the diagrams.
+type treeData struct {
+	Root  string      `json:"root" yaml:"root"`
+	Rows  []*treeRow  `json:"rows" yaml:"rows"`
+	Nodes []*treeNode `json:"nodes" yaml:"nodes"`
+	Edges []*treeEdge `json:"edges" yaml:"edges"`
+}
+
+type treeNode struct {
+	Key       string           `json:"key" yaml:"key"`
+	Summary   string           `json:"summary" yaml:"summary"`
+	Status    *jiradata.Status `json:"status" yaml:"status"`
+	IssueType string           `json:"issuetype" yaml:"issuetype"`
+	Assignee  string           `json:"assignee" yaml:"assignee"`
+	URL       string           `json:"url" yaml:"url"`
+}
+
+type treeRow struct {
+	*treeNode `yaml:",inline"`
+	// Prefix has the lines drawing the tree before the issue
+	Prefix string `json:"prefix" yaml:"prefix"`
+	Depth  int    `json:"depth" yaml:"depth"`
+	// Relation is how the issue is related to the issue above it, like
+	// "epic", "subtask" or a link description like "blocks"
+	Relation string `json:"relation" yaml:"relation"`
+	// Cycle is set when the issue is also above this row in the tree, and
+	// Repeated when the issue was already shown somewhere else.  The
+	// children are not shown again in either case.
+	Cycle    bool `json:"cycle" yaml:"cycle"`
+	Repeated bool `json:"repeated" yaml:"repeated"`
+}
+
+type treeEdge struct {
+	From     string `json:"from" yaml:"from"`
+	To       string `json:"to" yaml:"to"`
+	Relation string `json:"relation" yaml:"relation"`
+	Cycle    bool   `json:"cycle" yaml:"cycle"`
+}
+
+func CmdTreeRegistry() *jiracli.CommandRegistryEntry {
+	opts := TreeOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("tree"),
+		},
+		Depth:  3,
+		Output: "tree",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the epic, story and sub-task hierarchy of an issue, with its links",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTreeUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdTree(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTreeUsage(cmd *kingpin.CmdClause, opts *TreeOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("depth", "How many levels below the issue to show").IntVar(&opts.Depth)
+	cmd.Flag("links", "Comma separated link types to follow, like blocks,relates or all").StringsVar(&opts.Links)
+	cmd.Flag("output", "Output format: tree, dot or mermaid").Short('o').EnumVar(&opts.Output, "tree", "dot", "mermaid")
+	jiracli.IssueArgUsage(cmd, "issue id to show the tree for", &opts.Issue)
+	return nil
+}
+
+// CmdTree will walk the epic children, sub-tasks and links from the issue
+// and send the tree to the "tree" template, or the "tree-dot" or
+// "tree-mermaid" template for the diagrams.
+func CmdTree(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TreeOptions) error {
+	if opts.Output != "tree" && opts.Template.Value == "tree" {
+		opts.Template.Value = "tree-" + opts.Output
+	}
+	links := []string{}
+	for _, link := range opts.Links {
+		for _, name := range strings.Split(link, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				links = append(links, strings.ToLower(name))
+			}
+		}
+	}
+
+	walker := &treeWalker{
+		o:        o,
+		endpoint: globals.Endpoint.Value,
+		depth:    opts.Depth,
+		links:    links,
+		issues:   map[string]*jiradata.Issue{},
+		nodes:    map[string]*treeNode{},
+		shown:    map[string]bool{},
+		epics:    map[string]bool{},
+		data:     &treeData{Root: opts.Issue, Rows: []*treeRow{}, Nodes: []*treeNode{}, Edges: []*treeEdge{}},
+	}
+	root, err := walker.issue(opts.Issue)
+	if err != nil {
+		return err
+	}
+	walker.data.Root = root.Key
+	if err := walker.walk(root, "", "", treeChild{}, 0, map[string]bool{}); err != nil {
+		return err
+	}
+	return opts.PrintTemplate(walker.data)
+}
+
+var treeFields = []string{"summary", "status", "issuetype", "assignee", "subtasks", "issuelinks"}
+
+type treeWalker struct {
+	o        *oreo.Client
+	endpoint string
+	depth    int
+	links    []string
+	// issues are the fetched issues by key
+	issues map[string]*jiradata.Issue
+	nodes  map[string]*treeNode
+	shown  map[string]bool
+	// epics records if the issue types without a hierarchyLevel are epics,
+	// by issue type id, so we only ask Jira once for each type
+	epics map[string]bool
+	data  *treeData
+}
+
+// treeChild is an issue below another in the tree
+type treeChild struct {
+	key      string
+	relation string
+	// link is the id of the issue link to the child
+	link string
+}
+
+func (w *treeWalker) issue(key string) (*jiradata.Issue, error) {
+	if issue, ok := w.issues[key]; ok {
+		return issue, nil
+	}
+	issue, err := jira.GetIssue(w.o, w.endpoint, key, &jira.IssueOptions{Fields: treeFields})
+	if err != nil {
+		return nil, err
+	}
+	w.issues[issue.Key] = issue
+	return issue, nil
+}
+
+func (w *treeWalker) node(issue *jiradata.Issue) *treeNode {
+	if node, ok := w.nodes[issue.Key]; ok {
+		return node
+	}
+	node := &treeNode{
+		Key:      issue.Key,
+		URL:      jira.URLJoin(w.endpoint, "browse", issue.Key),
+		Assignee: fieldUserName(issue.Fields["assignee"]),
+		Status:   &jiradata.Status{},
+	}
+	node.Summary, _ = issue.Fields["summary"].(string)
+	jiracli.ConvertType(issue.Fields["status"], node.Status)
+	if issuetype, ok := issue.Fields["issuetype"].(map[string]interface{}); ok {
+		node.IssueType, _ = issuetype["name"].(string)
+	}
+	w.nodes[issue.Key] = node
+	w.data.Nodes = append(w.data.Nodes, node)
+	return node
+}
+
+// walk adds the row for the issue then the rows for its children, the
+// ancestors are used to find cycles.  The link the issue was reached by is
+// not followed back to the parent.
+func (w *treeWalker) walk(issue *jiradata.Issue, prefix, childPrefix string, from treeChild, depth int, ancestors map[string]bool) error {
+	row := &treeRow{
+		treeNode: w.node(issue),
+		Prefix:   prefix,
+		Depth:    depth,
+		Relation: from.relation,
+		Cycle:    ancestors[issue.Key],
+		Repeated: w.shown[issue.Key] && !ancestors[issue.Key],
+	}
+	w.data.Rows = append(w.data.Rows, row)
+	if row.Cycle || row.Repeated {
+		return nil
+	}
+	w.shown[issue.Key] = true
+	if depth >= w.depth {
+		return nil
+	}
+
+	children, err := w.children(issue)
+	if err != nil {
+		return err
+	}
+	if from.link != "" {
+		for i, child := range children {
+			if child.link == from.link {
+				children = append(children[:i], children[i+1:]...)
+				break
+			}
+		}
+	}
+	ancestors[issue.Key] = true
+	defer delete(ancestors, issue.Key)
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		childIssue, err := w.issue(child.key)
+		if err != nil {
+			return err
+		}
+		w.data.Edges = append(w.data.Edges, &treeEdge{
+			From:     issue.Key,
+			To:       childIssue.Key,
+			Relation: child.relation,
+			Cycle:    ancestors[childIssue.Key],
+		})
+		if err := w.walk(childIssue, childPrefix+branch, childPrefix+indent, child, depth+1, ancestors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// children returns the issues in the epic, the issues with this issue as
+// their parent, the sub-tasks and the linked issues for the link types we
+// follow.  An issue is only returned once.
+func (w *treeWalker) children(issue *jiradata.Issue) ([]treeChild, error) {
+	children := []treeChild{}
+	seen := map[string]bool{}
+	add := func(child treeChild) {
+		if !seen[child.key] {
+			seen[child.key] = true
+			children = append(children, child)
+		}
+	}
+
+	level, hasLevel := treeHierarchyLevel(issue)
+	issueType := treeIssueTypeID(issue)
+	epic, known := w.epics[issueType]
+	if level > 0 || !hasLevel && !treeSubtask(issue) && (epic || !known) {
+		search := &jira.SearchOptions{
+			Query:       "ORDER BY key",
+			QueryFields: strings.Join(treeFields, ","),
+		}
+		found := jiradata.Issues{}
+		// the issue type names are translated, so without the hierarchy
+		// level we only know the issue type is an epic when Jira lets us
+		// list the issues in it
+		if results, err := jira.EpicSearch(w.o, w.endpoint, issue.Key, search, jira.WithAutoPagination()); err != nil {
+			log.Debugf("Unable to list the issues in %s as an epic: %s", issue.Key, err)
+			if !hasLevel && !known {
+				w.epics[issueType] = false
+			}
+		} else {
+			if !hasLevel {
+				w.epics[issueType] = true
+			}
+			found = append(found, results.Issues...)
+		}
+		if level > 0 {
+			// epics in team-managed projects use the parent field instead
+			search.Query = fmt.Sprintf("parent = %s ORDER BY key", issue.Key)
+			parents, err := jira.Search(w.o, w.endpoint, search, jira.WithAutoPagination())
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, parents.Issues...)
+		}
+		for _, child := range found {
+			if _, ok := w.issues[child.Key]; !ok {
+				w.issues[child.Key] = child
+			}
+			add(treeChild{key: child.Key, relation: "epic"})
+		}
+	}
+
+	subtasks, _ := issue.Fields["subtasks"].([]interface{})
+	for _, data := range subtasks {
+		if subtask, ok := data.(map[string]interface{}); ok {
+			add(treeChild{key: fmt.Sprint(subtask["key"]), relation: "subtask"})
+		}
+	}
+
+	links, _ := issue.Fields["issuelinks"].([]interface{})
+	for _, data := range links {
+		link, _ := data.(map[string]interface{})
+		linkType, _ := link["type"].(map[string]interface{})
+		if linkType == nil {
+			continue
+		}
+		for _, side := range []string{"outward", "inward"} {
+			linked, ok := link[side+"Issue"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			relation := fmt.Sprint(linkType[side])
+			if w.followLink(fmt.Sprint(linkType["name"]), relation) {
+				add(treeChild{key: fmt.Sprint(linked["key"]), relation: relation, link: fmt.Sprint(link["id"])})
+			}
+		}
+	}
+	return children, nil
+}
+
+// treeHierarchyLevel returns the hierarchyLevel of the issue type, 1 for
+// epics, 0 for standard issues and -1 for sub-tasks.  Only Jira Cloud returns
+// the level.
+func treeHierarchyLevel(issue *jiradata.Issue) (int, bool) {
+	issuetype, _ := issue.Fields["issuetype"].(map[string]interface{})
+	level, ok := issuetype["hierarchyLevel"].(float64)
+	return int(level), ok
+}
+
+// treeIssueTypeID returns the id of the issue type, or the name when the
+// id is missing.
+func treeIssueTypeID(issue *jiradata.Issue) string {
+	issuetype, _ := issue.Fields["issuetype"].(map[string]interface{})
+	if id, ok := issuetype["id"].(string); ok && id != "" {
+		return id
+	}
+	name, _ := issuetype["name"].(string)
+	return name
+}
+
+func treeSubtask(issue *jiradata.Issue) bool {
+	issuetype, _ := issue.Fields["issuetype"].(map[string]interface{})
+	subtask, _ := issuetype["subtask"].(bool)
+	return subtask
+}
+
+// followLink returns true when the link type name or description starts with
+// one of the --links values.
+func (w *treeWalker) followLink(name, relation string) bool {
+	for _, link := range w.links {
+		if link == "all" || strings.HasPrefix(strings.ToLower(name), link) || strings.HasPrefix(strings.ToLower(relation), link) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package jiracmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+// treeTestIssue is a standard issue on Jira Cloud, so the walker does not
+// need to ask Jira if it is an epic.  Links are "blocks" links by key.
+func treeTestIssue(key string, subtasks []string, blocks ...string) *jiradata.Issue {
+	fields := map[string]interface{}{
+		"summary":   "Issue " + key,
+		"status":    map[string]interface{}{"name": "To Do"},
+		"issuetype": map[string]interface{}{"id": "10001", "name": "Story", "hierarchyLevel": 0.0},
+	}
+	list := []interface{}{}
+	for _, subtask := range subtasks {
+		list = append(list, map[string]interface{}{"key": subtask})
+	}
+	fields["subtasks"] = list
+	links := []interface{}{}
+	for _, blocked := range blocks {
+		links = append(links, map[string]interface{}{
+			"id":           key + ">" + blocked,
+			"type":         map[string]interface{}{"name": "Blocks", "outward": "blocks", "inward": "is blocked by"},
+			"outwardIssue": map[string]interface{}{"key": blocked},
+		})
+	}
+	fields["issuelinks"] = links
+	return &jiradata.Issue{Key: key, Fields: fields}
+}
+
+func treeTestWalker(depth int, links []string, issues ...*jiradata.Issue) *treeWalker {
+	walker := &treeWalker{
+		endpoint: "https://jira.example.com",
+		depth:    depth,
+		links:    links,
+		issues:   map[string]*jiradata.Issue{},
+		nodes:    map[string]*treeNode{},
+		shown:    map[string]bool{},
+		epics:    map[string]bool{},
+		data:     &treeData{Rows: []*treeRow{}, Nodes: []*treeNode{}, Edges: []*treeEdge{}},
+	}
+	for _, issue := range issues {
+		walker.issues[issue.Key] = issue
+	}
+	return walker
+}
+
+func treeRows(data *treeData) []string {
+	rows := []string{}
+	for _, row := range data.Rows {
+		line := row.Prefix + row.Key
+		if row.Relation != "" {
+			line += " " + row.Relation
+		}
+		if row.Cycle {
+			line += " (cycle)"
+		}
+		if row.Repeated {
+			line += " (see above)"
+		}
+		rows = append(rows, line)
+	}
+	return rows
+}
+
+func TestTreeWalk(t *testing.T) {
+	// A blocks B and C, B blocks D, C blocks D and A, D blocks B
+	walker := treeTestWalker(5, []string{"blocks"},
+		treeTestIssue("A", []string{"A1"}, "B", "C"),
+		treeTestIssue("A1", nil),
+		treeTestIssue("B", nil, "D"),
+		treeTestIssue("C", nil, "D", "A"),
+		treeTestIssue("D", nil, "B"),
+	)
+	root := walker.issues["A"]
+	assert.NoError(t, walker.walk(root, "", "", treeChild{}, 0, map[string]bool{}))
+
+	assert.Equal(t, []string{
+		"A",
+		"├── A1 subtask",
+		"├── B blocks",
+		"│   └── D blocks",
+		"│       └── B blocks (cycle)",
+		"└── C blocks",
+		"    ├── D blocks (see above)",
+		"    └── A blocks (cycle)",
+	}, treeRows(walker.data))
+
+	cycles := []string{}
+	for _, edge := range walker.data.Edges {
+		if edge.Cycle {
+			cycles = append(cycles, edge.From+"->"+edge.To)
+		}
+	}
+	assert.Equal(t, []string{"D->B", "C->A"}, cycles)
+	assert.Len(t, walker.data.Edges, 7)
+	// each issue is a single node for the diagrams
+	assert.Len(t, walker.data.Nodes, 5)
+}
+
+func TestTreeWalkDepth(t *testing.T) {
+	walker := treeTestWalker(1, []string{"all"},
+		treeTestIssue("A", nil, "B"),
+		treeTestIssue("B", nil, "C"),
+	)
+	assert.NoError(t, walker.walk(walker.issues["A"], "", "", treeChild{}, 0, map[string]bool{}))
+	assert.Equal(t, []string{"A", "└── B blocks"}, treeRows(walker.data))
+
+	// the link we came from is not followed back
+	walker = treeTestWalker(3, []string{"blocks"}, treeTestIssue("A", nil, "B"), treeTestIssue("B", nil))
+	walker.issues["B"].Fields["issuelinks"] = []interface{}{map[string]interface{}{
+		"id":          "A>B",
+		"type":        map[string]interface{}{"name": "Blocks", "outward": "blocks", "inward": "is blocked by"},
+		"inwardIssue": map[string]interface{}{"key": "A"},
+	}}
+	assert.NoError(t, walker.walk(walker.issues["A"], "", "", treeChild{}, 0, map[string]bool{}))
+	assert.Equal(t, []string{"A", "└── B blocks"}, treeRows(walker.data))
+
+	// links are only followed when asked for
+	walker = treeTestWalker(3, nil, treeTestIssue("A", nil, "B"), treeTestIssue("B", nil))
+	assert.NoError(t, walker.walk(walker.issues["A"], "", "", treeChild{}, 0, map[string]bool{}))
+	assert.Equal(t, []string{"A"}, treeRows(walker.data))
+}
+
+func TestTreeServerEpics(t *testing.T) {
+	// Jira Server does not return the hierarchyLevel, so the walker asks
+	// once for each issue type if it is an epic
+	issueType := func(id, name string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "name": name, "subtask": false}
+	}
+	issues := map[string]*jiradata.Issue{
+		"EPIC-1": {Key: "EPIC-1", Fields: map[string]interface{}{"issuetype": issueType("10000", "Épica"), "summary": "epic"}},
+		"EPIC-2": {Key: "EPIC-2", Fields: map[string]interface{}{"issuetype": issueType("10000", "Épica"), "summary": "empty epic"}},
+		"S-1":    {Key: "S-1", Fields: map[string]interface{}{"issuetype": issueType("10001", "Historia"), "summary": "story"}},
+		"S-2":    {Key: "S-2", Fields: map[string]interface{}{"issuetype": issueType("10001", "Historia"), "summary": "story"}},
+	}
+	children := map[string][]string{"EPIC-1": {"S-1", "S-2", "EPIC-2"}, "EPIC-2": {}}
+
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasPrefix(r.URL.Path, "/rest/agile/1.0/epic/") {
+			w.WriteHeader(404)
+			return
+		}
+		key := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/agile/1.0/epic/"), "/")[0]
+		requests = append(requests, key)
+		keys, ok := children[key]
+		if !ok {
+			w.WriteHeader(400)
+			fmt.Fprintf(w, `{"errorMessages":["Issue %s is not an epic"]}`, key)
+			return
+		}
+		results := &jiradata.SearchResults{Total: len(keys), Issues: jiradata.Issues{}}
+		for _, child := range keys {
+			results.Issues = append(results.Issues, issues[child])
+		}
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+
+	walker := treeTestWalker(3, nil)
+	walker.o = oreo.New().WithRetries(0)
+	walker.endpoint = server.URL
+	assert.NoError(t, walker.walk(issues["EPIC-1"], "", "", treeChild{}, 0, map[string]bool{}))
+	assert.Equal(t, []string{
+		"EPIC-1",
+		"├── S-1 epic",
+		"├── S-2 epic",
+		"└── EPIC-2 epic",
+	}, treeRows(walker.data))
+	// S-2 is not checked again after S-1 was not an epic
+	assert.Equal(t, []string{"EPIC-1", "S-1", "EPIC-2"}, requests)
+	assert.Equal(t, map[string]bool{"10000": true, "10001": false}, walker.epics)
+}
@@ -0,0 +1,500 @@
+package jiracmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TuiOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	jira.SearchOptions    `yaml:",inline" json:",inline" figtree:",inline"`
+}
+
+func CmdTuiRegistry() *jiracli.CommandRegistryEntry {
+	opts := TuiOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("view"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Interactive full-screen interface to browse and triage issues",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTuiUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.QueryFields == "" {
+				opts.QueryFields = "assignee,issuetype,priority,status"
+			}
+			if opts.Sort == "" {
+				opts.Sort = "priority asc, key"
+			}
+			return CmdTui(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTuiUsage(cmd *kingpin.CmdClause, opts *TuiOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("assignee", "User assigned the issue").Short('a').StringVar(&opts.Assignee)
+	cmd.Flag("component", "Component to search for").Short('c').HintAction(componentHint(&opts.Project)).StringVar(&opts.Component)
+	cmd.Flag("issuetype", "Issue type to search for").Short('i').HintAction(issueTypeHint(&opts.Project)).StringVar(&opts.IssueType)
+	cmd.Flag("limit", "Maximum number of results to return in search").Short('l').IntVar(&opts.MaxResults)
+	cmd.Flag("project", "Project to search for").Short('p').StringVar(&opts.Project)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the search").Short('q').StringVar(&opts.Query)
+	cmd.Flag("reporter", "Reporter to search for").Short('r').StringVar(&opts.Reporter)
+	cmd.Flag("status", "Filter on issue status").Short('S').StringVar(&opts.Status)
+	cmd.Flag("sort", "Sort order to return").Short('s').StringVar(&opts.Sort)
+	return nil
+}
+
+const tuiHelp = "j/k:move  J/K:scroll  t:transition  a:assign  c:comment  l:labels  o:open  r:refresh  q:quit"
+
+// tui holds the state of the full-screen interface: the issues found by the
+// search, the selected issue and the rendered details for each issue.
+type tui struct {
+	o       *oreo.Client
+	globals *jiracli.GlobalOptions
+	opts    *TuiOptions
+	out     *bufio.Writer
+	issues  []*jiradata.Issue
+	cursor  int
+	offset  int
+	scroll  int
+	details map[string][]string
+	status  string
+	stop    func()
+}
+
+// CmdTui will search for issues and present them in an interactive
+// full-screen interface.  The actions reuse the existing commands, so the
+// screen is restored while they run and any editor or prompt works as usual.
+func CmdTui(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TuiOptions) error {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("The tui command requires an interactive terminal")
+	}
+
+	t := &tui{
+		o:       o,
+		globals: globals,
+		opts:    opts,
+		out:     bufio.NewWriter(os.Stdout),
+		details: map[string][]string{},
+	}
+	if err := t.search(); err != nil {
+		return err
+	}
+	if len(t.issues) == 0 {
+		return fmt.Errorf("No issues found")
+	}
+
+	if err := t.start(); err != nil {
+		return err
+	}
+	defer t.stop()
+
+	for {
+		t.draw()
+		key, err := t.readKey()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "q", "ctrl-c":
+			return nil
+		case "j", "down":
+			t.move(1)
+		case "k", "up":
+			t.move(-1)
+		case "g", "home":
+			t.move(-len(t.issues))
+		case "G", "end":
+			t.move(len(t.issues))
+		case "J", " ", "pgdn":
+			t.scroll += t.detailHeight() / 2
+		case "K", "b", "pgup":
+			t.scroll -= t.detailHeight() / 2
+		case "r":
+			t.details = map[string][]string{}
+			if err := t.search(); err != nil {
+				t.status = err.Error()
+			} else {
+				t.status = fmt.Sprintf("Found %d issues", len(t.issues))
+			}
+		case "o":
+			if issue := t.current(); issue == nil {
+				t.status = "No issue selected"
+			} else if err := CmdBrowse(t.globals, issue.Key); err != nil {
+				t.status = err.Error()
+			}
+		case "t":
+			err = t.run(t.transition)
+		case "a":
+			err = t.run(t.assign)
+		case "c":
+			err = t.run(t.comment)
+		case "l":
+			err = t.run(t.labels)
+		default:
+			t.status = tuiHelp
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (t *tui) search() error {
+	data, err := jira.Search(t.o, t.globals.Endpoint.Value, &t.opts.SearchOptions, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+	t.issues = data.Issues
+	// the list can be shorter after a refresh, so keep the cursor and the
+	// first row shown within the list
+	t.move(0)
+	if last := len(t.issues) - t.listHeight(); t.offset > last {
+		t.offset = last
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+	return nil
+}
+
+// current returns the selected issue, or nil when the list is empty.
+func (t *tui) current() *jiradata.Issue {
+	if t.cursor >= len(t.issues) {
+		return nil
+	}
+	return t.issues[t.cursor]
+}
+
+func (t *tui) move(delta int) {
+	t.cursor += delta
+	if t.cursor >= len(t.issues) {
+		t.cursor = len(t.issues) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	if delta != 0 {
+		t.scroll = 0
+	}
+}
+
+// start switches to the alternate screen with line wrapping disabled so
+// that long lines are clipped rather than scrolling the screen.
+func (t *tui) start() error {
+	state, err := terminal.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	t.stop = func() {
+		fmt.Fprint(t.out, "\x1b[?7h\x1b[?25h\x1b[?1049l")
+		t.out.Flush()
+		terminal.Restore(int(os.Stdin.Fd()), state)
+	}
+	fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l\x1b[?7l")
+	return nil
+}
+
+func (t *tui) size() (int, int) {
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 80, 24
+	}
+	return width, height
+}
+
+func (t *tui) listHeight() int {
+	_, height := t.size()
+	rows := height / 3
+	if rows < 3 {
+		rows = 3
+	}
+	if rows > len(t.issues) {
+		rows = len(t.issues)
+	}
+	return rows
+}
+
+func (t *tui) detailHeight() int {
+	_, height := t.size()
+	// title, separator and status lines
+	return height - t.listHeight() - 3
+}
+
+func (t *tui) draw() {
+	width, height := t.size()
+	line := 1
+	printLine := func(format string, args ...interface{}) {
+		if line > height {
+			return
+		}
+		fmt.Fprintf(t.out, "\x1b[%d;1H\x1b[2K", line)
+		fmt.Fprintf(t.out, format, args...)
+		fmt.Fprint(t.out, "\x1b[0m")
+		line++
+	}
+
+	title := t.opts.Query
+	if title == "" {
+		title = t.opts.ProvideSearchRequest().JQL
+	}
+	printLine("\x1b[7m%s", clip(fmt.Sprintf(" %d issues: %s", len(t.issues), title), width, true))
+
+	rows := t.listHeight()
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+rows && rows > 0 {
+		t.offset = t.cursor - rows + 1
+	}
+	for i := t.offset; i < t.offset+rows && i < len(t.issues); i++ {
+		issue := t.issues[i]
+		row := fmt.Sprintf("%-12s %-14s %-16s %s",
+			issue.Key,
+			clip(issueFieldName(issue, "status"), 14, false),
+			clip(issueFieldName(issue, "assignee"), 16, false),
+			issueFieldString(issue, "summary"),
+		)
+		if i == t.cursor {
+			printLine("\x1b[7m%s", clip(row, width, true))
+		} else {
+			printLine("%s", clip(row, width, false))
+		}
+	}
+
+	printLine("\x1b[1m%s", strings.Repeat("-", width))
+
+	details := []string{"No issues found"}
+	if issue := t.current(); issue != nil {
+		details = t.renderDetails(issue)
+	}
+	detailRows := t.detailHeight()
+	if t.scroll > len(details)-detailRows {
+		t.scroll = len(details) - detailRows
+	}
+	if t.scroll < 0 {
+		t.scroll = 0
+	}
+	for i := t.scroll; i < t.scroll+detailRows; i++ {
+		if i < len(details) {
+			printLine("%s", details[i])
+		} else {
+			printLine("")
+		}
+	}
+
+	status := t.status
+	if status == "" {
+		status = tuiHelp
+	}
+	line = height
+	printLine("\x1b[7m%s", clip(" "+status, width, true))
+	t.status = ""
+	t.out.Flush()
+}
+
+// renderDetails will run the view template for the issue, the results are
+// cached until the issue is modified or the list is refreshed.
+func (t *tui) renderDetails(issue *jiradata.Issue) []string {
+	if lines, ok := t.details[issue.Key]; ok {
+		return lines
+	}
+	buf := bytes.NewBufferString("")
+	full, err := jira.GetIssue(t.o, t.globals.Endpoint.Value, issue.Key, nil)
+	if err == nil {
+		err = jiracli.RunTemplate(t.opts.Template.Value, issueViewData(t.o, t.globals.Endpoint.Value, full), buf)
+	}
+	if err != nil {
+		return []string{err.Error()}
+	}
+	lines := strings.Split(strings.Replace(buf.String(), "\t", "    ", -1), "\n")
+	t.details[issue.Key] = lines
+	return lines
+}
+
+func (t *tui) readKey() (string, error) {
+	buf := make([]byte, 8)
+	n, err := os.Stdin.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	switch key := string(buf[:n]); key {
+	case "\x1b[A", "\x1bOA":
+		return "up", nil
+	case "\x1b[B", "\x1bOB":
+		return "down", nil
+	case "\x1b[5~":
+		return "pgup", nil
+	case "\x1b[6~":
+		return "pgdn", nil
+	case "\x1b[H", "\x1b[1~":
+		return "home", nil
+	case "\x1b[F", "\x1b[4~":
+		return "end", nil
+	case "\x03":
+		return "ctrl-c", nil
+	default:
+		return key, nil
+	}
+}
+
+// run will restore the terminal while the action runs, then wait for a key
+// press so the results can be read before returning to the interface.  The
+// error is only returned when the terminal cannot be set up again.
+func (t *tui) run(action func(issue string) error) error {
+	current := t.current()
+	if current == nil {
+		t.status = "No issue selected"
+		return nil
+	}
+	issue := current.Key
+	t.stop()
+	err := action(issue)
+	// the action may have used a client without the cache
+	jiracli.InvalidateIssueCache(t.globals, issue)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR %s\n", err)
+	}
+	fmt.Print("Press any key to continue ...")
+	if err := t.start(); err != nil {
+		return err
+	}
+	t.readKey()
+
+	if err != nil {
+		t.status = fmt.Sprintf("%s: %s", issue, err)
+	}
+	delete(t.details, issue)
+	if updated, err := jira.GetIssue(t.o, t.globals.Endpoint.Value, issue, nil); err == nil {
+		t.issues[t.cursor] = updated
+	}
+	return nil
+}
+
+func (t *tui) transition(issue string) error {
+	meta, err := jira.GetIssueTransitions(t.o, t.globals.Endpoint.Value, issue)
+	if err != nil {
+		return err
+	}
+	choices := []string{}
+	for _, trans := range meta.Transitions {
+		choices = append(choices, trans.Name)
+	}
+	var answer string
+	err = survey.AskOne(&survey.Select{Message: fmt.Sprintf("Transition %s to:", issue), Options: choices}, &answer, nil)
+	if err != nil {
+		return err
+	}
+	return CmdTransition(t.o, t.globals, &TransitionOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template:    figtree.NewStringOption("transition"),
+			SkipEditing: figtree.NewBoolOption(true),
+		},
+		Overrides:  map[string]string{},
+		Transition: answer,
+		Issue:      issue,
+	})
+}
+
+func (t *tui) assign(issue string) error {
+	var answer string
+	err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("Assign %s to (email or display name, - to unassign, empty to cancel):", issue)}, &answer, nil)
+	if err != nil {
+		return err
+	}
+	switch answer = strings.TrimSpace(answer); answer {
+	case "":
+		return nil
+	case "-":
+		// the same as the unassign command
+		answer = ""
+	}
+	return CmdAssign(t.o, t.globals, &AssignOptions{
+		Issue:    issue,
+		Assignee: answer,
+	})
+}
+
+func (t *tui) comment(issue string) error {
+	return CmdComment(t.o, t.globals, &CommentOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("comment"),
+		},
+		Overrides: map[string]string{},
+		Issue:     issue,
+	})
+}
+
+func (t *tui) labels(issue string) error {
+	var answer string
+	err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("Labels for %s (prefix with - to remove):", issue)}, &answer, nil)
+	if err != nil {
+		return err
+	}
+	add, remove := []string{}, []string{}
+	for _, label := range strings.Fields(answer) {
+		if strings.HasPrefix(label, "-") {
+			remove = append(remove, strings.TrimPrefix(label, "-"))
+		} else {
+			add = append(add, label)
+		}
+	}
+	if len(add) > 0 {
+		if err := CmdLabelsAdd(t.o, t.globals, &LabelsAddOptions{Issue: issue, Labels: add}); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		return CmdLabelsRemove(t.o, t.globals, &LabelsRemoveOptions{Issue: issue, Labels: remove})
+	}
+	return nil
+}
+
+// issueFieldName returns the "displayName" or "name" of an object field like
+// status or assignee.
+func issueFieldName(issue *jiradata.Issue, field string) string {
+	if value, ok := issue.Fields[field].(map[string]interface{}); ok {
+		for _, key := range []string{"displayName", "name"} {
+			if name, ok := value[key].(string); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func issueFieldString(issue *jiradata.Issue, field string) string {
+	if value, ok := issue.Fields[field].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// clip will truncate (or pad when requested) the string to the given width
+func clip(s string, width int, pad bool) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	if pad {
+		return s + strings.Repeat(" ", width-len(runes))
+	}
+	return s
+}
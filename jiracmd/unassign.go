@@ -25,6 +25,6 @@ func CmdUnassignRegistry() *jiracli.CommandRegistryEntry {
 
 func CmdUnassignUsage(cmd *kingpin.CmdClause, opts *AssignOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue to unassign").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue to unassign", &opts.Issue)
 	return nil
 }
@@ -0,0 +1,119 @@
+package jiracmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type UserSearchOptions struct {
+	jiracli.CommonOptions  `yaml:",inline" json:",inline" figtree:",inline"`
+	jira.UserSearchOptions `yaml:",inline" json:",inline" figtree:",inline"`
+}
+
+func CmdUserSearchRegistry() *jiracli.CommandRegistryEntry {
+	opts := UserSearchOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("users"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Search for users by display name or email address",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdUserSearchUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdUserSearch(o, globals, &opts)
+		},
+	}
+}
+
+func CmdUserSearchUsage(cmd *kingpin.CmdClause, opts *UserSearchOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("limit", "Maximum number of users to return").Short('l').IntVar(&opts.MaxResults)
+	cmd.Arg("QUERY", "display name or email address to search for").Required().StringVar(&opts.Query)
+	return nil
+}
+
+// CmdUserSearch will send the users matching the query to the "users" template
+func CmdUserSearch(o *oreo.Client, globals *jiracli.GlobalOptions, opts *UserSearchOptions) error {
+	data, err := jira.UserSearch(o, globals.Endpoint.Value, &opts.UserSearchOptions)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
+
+// findUserAccountID will search for users by display name or email address
+// and return the account id of the match.  If several users match and we are
+// running in a terminal the user is prompted to pick one, otherwise an error
+// is returned.  When nothing matches the query is returned unchanged.
+func findUserAccountID(ua jira.HttpClient, endpoint string, query string) (string, error) {
+	users, err := jira.UserSearch(ua, endpoint, &jira.UserSearchOptions{
+		// Query field will search users displayName and emailAddress
+		Query: query,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(users) == 0 {
+		return query, nil
+	}
+	if len(users) == 1 {
+		return users[0].AccountID, nil
+	}
+
+	// an exact email address or display name match wins over partial matches
+	exact := []*jiradata.User{}
+	for _, user := range users {
+		if strings.EqualFold(user.EmailAddress, query) || strings.EqualFold(user.DisplayName, query) {
+			exact = append(exact, user)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0].AccountID, nil
+	}
+
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return "", fmt.Errorf("Found %d accounts for users with query %q", len(users), query)
+	}
+
+	choices := []string{}
+	for _, user := range users {
+		choice := user.DisplayName
+		if user.EmailAddress != "" {
+			choice += fmt.Sprintf(" <%s>", user.EmailAddress)
+		}
+		choices = append(choices, fmt.Sprintf("%s [%s]", choice, user.AccountID))
+	}
+	var answer string
+	err = survey.AskOne(
+		&survey.Select{
+			Message: fmt.Sprintf("Found %d accounts for %q, select one:", len(users), query),
+			Options: choices,
+		},
+		&answer,
+		nil,
+	)
+	if err != nil {
+		return "", err
+	}
+	for i, choice := range choices {
+		if choice == answer {
+			return users[i].AccountID, nil
+		}
+	}
+	return "", fmt.Errorf("No account selected for %q", query)
+}
@@ -0,0 +1,49 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type UserShowOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	AccountID             string `yaml:"accountId,omitempty" json:"accountId,omitempty"`
+}
+
+func CmdUserShowRegistry() *jiracli.CommandRegistryEntry {
+	opts := UserShowOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("user"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints user details",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdUserShowUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdUserShow(o, globals, &opts)
+		},
+	}
+}
+
+func CmdUserShowUsage(cmd *kingpin.CmdClause, opts *UserShowOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ACCOUNTID", "account id of user").Required().StringVar(&opts.AccountID)
+	return nil
+}
+
+// CmdUserShow will send the user details to the "user" template
+func CmdUserShow(o *oreo.Client, globals *jiracli.GlobalOptions, opts *UserShowOptions) error {
+	data, err := jira.GetUser(o, globals.Endpoint.Value, opts.AccountID)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -0,0 +1,33 @@
+package jiracmd
+
+import "github.com/go-jira/jira/jiradata"
+
+// issueEpicKey returns the key of the epic the issue belongs to, from the
+// Epic Link field or the parent for projects that use the parent for epics.
+func issueEpicKey(issue *jiradata.Issue, epicLink string) string {
+	if epic, ok := issue.Fields[epicLink].(string); ok && epicLink != "" && epic != "" {
+		return epic
+	}
+	if parent, ok := issue.Fields["parent"].(map[string]interface{}); ok {
+		if parentFields, ok := parent["fields"].(map[string]interface{}); ok {
+			if issuetype, ok := parentFields["issuetype"].(map[string]interface{}); ok && issuetype["name"] == "Epic" {
+				epic, _ := parent["key"].(string)
+				return epic
+			}
+		}
+	}
+	return ""
+}
+
+// fieldUserName returns the name to show for a user field like the assignee,
+// or an empty string when the field is not set.
+func fieldUserName(data interface{}) string {
+	if user, ok := data.(map[string]interface{}); ok {
+		for _, key := range []string{"displayName", "name", "accountId"} {
+			if name, ok := user[key].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/coryb/oreo"
 	"github.com/go-jira/jira"
 	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -42,16 +43,25 @@ func CmdViewUsage(cmd *kingpin.CmdClause, opts *ViewOptions) error {
 	cmd.Flag("expand", "field to expand for the issue").StringsVar(&opts.Expand)
 	cmd.Flag("field", "field to return for the issue").StringsVar(&opts.Fields)
 	cmd.Flag("property", "property to return for issue").StringsVar(&opts.Properties)
-	cmd.Arg("ISSUE", "issue id to view").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue id to view", &opts.Issue)
 	return nil
 }
 
 // View will get issue data and send to "view" template
 func CmdView(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ViewOptions) error {
-	data, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, opts)
+	for i, name := range opts.Fields {
+		id, err := jiracli.FieldID(name)
+		if err != nil {
+			return jiracli.CliError(err)
+		}
+		opts.Fields[i] = id
+	}
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, opts)
 	if err != nil {
 		return err
 	}
+
+	data := issueViewData(o, globals.Endpoint.Value, issue)
 	if err := opts.PrintTemplate(data); err != nil {
 		return err
 	}
@@ -60,3 +70,30 @@ func CmdView(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ViewOptions)
 	}
 	return nil
 }
+
+// viewTemplateInput is the issue data augmented with the details that are
+// not part of the issue resource, sent to the "view" template.
+type viewTemplateInput struct {
+	*jiradata.Issue `yaml:",inline"`
+	RemoteLinks     jiradata.RemoteIssueLinks `yaml:"remotelinks,omitempty" json:"remotelinks,omitempty"`
+	Watchers        []*jiradata.User          `yaml:"watchers,omitempty" json:"watchers,omitempty"`
+}
+
+func issueViewData(o *oreo.Client, endpoint string, issue *jiradata.Issue) *viewTemplateInput {
+	data := &viewTemplateInput{
+		Issue: issue,
+	}
+	// remote links can be disabled on the server, so do not fail the view
+	// if we cannot fetch them
+	if links, err := jira.GetRemoteLinks(o, endpoint, issue.Key); err != nil {
+		log.Debugf("Unable to fetch remote links for %s: %s", issue.Key, err)
+	} else {
+		data.RemoteLinks = *links
+	}
+	if watchers, err := jira.GetIssueWatchers(o, endpoint, issue.Key); err != nil {
+		log.Debugf("Unable to fetch watchers for %s: %s", issue.Key, err)
+	} else {
+		data.Watchers = watchers.Watchers
+	}
+	return data
+}
@@ -50,7 +50,7 @@ func CmdVoteUsage(cmd *kingpin.CmdClause, opts *VoteOptions) error {
 		opts.Action = VoteDown
 		return nil
 	}).Bool()
-	cmd.Arg("ISSUE", "issue id to vote").StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue id to vote", &opts.Issue)
 	return nil
 }
 
@@ -23,7 +23,9 @@ type WatchOptions struct {
 	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
 	Project               string      `yaml:"project,omitempty" json:"project,omitempty"`
 	Issue                 string      `yaml:"issue,omitempty" json:"issue,omitempty"`
-	Watcher               string      `yaml:"watcher,omitempty" json:"watcher,omitempty"`
+	Watchers              []string    `yaml:"watchers,omitempty" json:"watchers,omitempty"`
+	Watcher               string      `yaml:"watcher,omitempty" json:"watcher,omitempty"` // deprecated, use watchers
+	Query                 string      `yaml:"query,omitempty" json:"query,omitempty"`
 	Action                WatchAction `yaml:"-" json:"-"`
 }
 
@@ -36,11 +38,27 @@ func CmdWatchRegistry() *jiracli.CommandRegistryEntry {
 	return &jiracli.CommandRegistryEntry{
 		"Add/Remove watcher to issue",
 		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			// this was the "watch" command before "watch list" was added, so
+			// keep reading the watch.yml configs
+			cmd.PreAction(func(_ *kingpin.ParseContext) error {
+				return fig.LoadAllConfigs("watch.yml", &opts)
+			})
 			jiracli.LoadConfigs(cmd, fig, &opts)
 			return CmdWatchUsage(cmd, &opts)
 		},
 		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
-			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			if opts.Query != "" {
+				// when searching for issues all the arguments are watchers
+				if opts.Issue != "" {
+					opts.Watchers = append([]string{opts.Issue}, opts.Watchers...)
+					opts.Issue = ""
+				}
+			} else {
+				if opts.Issue == "" {
+					return jiracli.CliError(fmt.Errorf("ISSUE argument or --query is required"))
+				}
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
 			return CmdWatch(o, globals, &opts)
 		},
 	}
@@ -52,57 +70,86 @@ func CmdWatchUsage(cmd *kingpin.CmdClause, opts *WatchOptions) error {
 		opts.Action = WatcherRemove
 		return nil
 	}).Bool()
-	cmd.Arg("ISSUE", "issue to add watcher").Required().StringVar(&opts.Issue)
-	cmd.Arg("WATCHER", "email or display name of watcher to add to issue").StringVar(&opts.Watcher)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the search to watch multiple issues").Short('q').StringVar(&opts.Query)
+	jiracli.OptionalIssueArgUsage(cmd, "issue to add watcher", &opts.Issue)
+	jiracli.SkipIssuePicker(cmd, func() bool { return opts.Query != "" })
+	cmd.Arg("WATCHER", "email or display name of watchers to add to issue").StringsVar(&opts.Watchers)
 	return nil
 }
 
-// CmdWatch will add the given watcher to the issue (or remove the watcher
-// with the 'remove' flag)
+// CmdWatch will add the given watchers to the issue, or to every issue
+// matching the query (or remove the watchers with the 'remove' flag)
 func CmdWatch(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WatchOptions) error {
-	if opts.Watcher == "" {
-		opts.Watcher = globals.Login.Value
+	// configs written before "watchers" was a list use the "watcher" key
+	if opts.Watcher != "" {
+		found := false
+		for _, watcher := range opts.Watchers {
+			found = found || watcher == opts.Watcher
+		}
+		if !found {
+			opts.Watchers = append(opts.Watchers, opts.Watcher)
+		}
+	}
+	if len(opts.Watchers) == 0 {
+		opts.Watchers = []string{globals.Login.Value}
 	}
 
 	if globals.JiraDeploymentType.Value == "" {
-		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		serverInfo, err := jira.ServerInfo(jiracli.CacheClient(o, globals), globals.Endpoint.Value)
 		if err != nil {
 			return err
 		}
 		globals.JiraDeploymentType.Value = strings.ToLower(serverInfo.DeploymentType)
 	}
 
-	if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
-		users, err := jira.UserSearch(o, globals.Endpoint.Value, &jira.UserSearchOptions{
-			Query: opts.Watcher,
-		})
+	watchers := []string{}
+	for _, watcher := range opts.Watchers {
+		if globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType {
+			accountID, err := findUserAccountID(o, globals.Endpoint.Value, watcher)
+			if err != nil {
+				return err
+			}
+			watcher = accountID
+		}
+		watchers = append(watchers, watcher)
+	}
+
+	issues := []string{opts.Issue}
+	if opts.Query != "" {
+		results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query: opts.Query,
+		}, jira.WithAutoPagination())
 		if err != nil {
 			return err
 		}
-		if len(users) > 1 {
-			return fmt.Errorf("Found %d accounts for users with username %q", len(users), opts.Watcher)
-		} else if len(users) == 1 {
-			opts.Watcher = users[0].AccountID
+		issues = []string{}
+		for _, issue := range results.Issues {
+			issues = append(issues, issue.Key)
 		}
 	}
 
-	if opts.Action == WatcherAdd {
-		if err := jira.IssueAddWatcher(o, globals.Endpoint.Value, opts.Issue, opts.Watcher); err != nil {
-			return err
+	for _, issue := range issues {
+		for _, watcher := range watchers {
+			if opts.Action == WatcherAdd {
+				if err := jira.IssueAddWatcher(o, globals.Endpoint.Value, issue, watcher); err != nil {
+					return err
+				}
+			} else {
+				if err := jira.IssueRemoveWatcher(o, globals.Endpoint.Value, issue, watcher); err != nil {
+					return err
+				}
+			}
 		}
-	} else {
-		if err := jira.IssueRemoveWatcher(o, globals.Endpoint.Value, opts.Issue, opts.Watcher); err != nil {
-			return err
+
+		if !globals.Quiet.Value {
+			fmt.Printf("OK %s %s\n", issue, jira.URLJoin(globals.Endpoint.Value, "browse", issue))
 		}
-	}
 
-	if !globals.Quiet.Value {
-		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
 	}
 
-	if opts.Browse.Value {
+	// only open the browser for a single issue, not for every match
+	if opts.Browse.Value && opts.Query == "" {
 		return CmdBrowse(globals, opts.Issue)
 	}
-
 	return nil
 }
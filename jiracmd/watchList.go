@@ -0,0 +1,51 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type WatchListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdWatchListRegistry() *jiracli.CommandRegistryEntry {
+	opts := WatchListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("watchers"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the watchers of an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdWatchListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdWatchList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdWatchListUsage(cmd *kingpin.CmdClause, opts *WatchListOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	jiracli.IssueArgUsage(cmd, "issue id to list watchers", &opts.Issue)
+	return nil
+}
+
+// CmdWatchList will get the issue watchers and send to the "watchers" template
+func CmdWatchList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WatchListOptions) error {
+	data, err := jira.GetIssueWatchers(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
@@ -0,0 +1,255 @@
+package jiracmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type WatchQueryOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Query                 string        `yaml:"query,omitempty" json:"query,omitempty"`
+	Interval              time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	JSON                  bool          `yaml:"json,omitempty" json:"json,omitempty"`
+	Notify                bool          `yaml:"notify,omitempty" json:"notify,omitempty"`
+	Hook                  string        `yaml:"hook,omitempty" json:"hook,omitempty"`
+	Initial               bool          `yaml:"initial,omitempty" json:"initial,omitempty"`
+}
+
+// WatchQueryEvent is a change found between two polls of the query
+type WatchQueryEvent struct {
+	// Type is one of "new", "removed", "status", "assignee" or "comment"
+	Type    string    `json:"type" yaml:"type"`
+	Issue   string    `json:"issue" yaml:"issue"`
+	Summary string    `json:"summary" yaml:"summary"`
+	From    string    `json:"from,omitempty" yaml:"from,omitempty"`
+	To      string    `json:"to,omitempty" yaml:"to,omitempty"`
+	Author  string    `json:"author,omitempty" yaml:"author,omitempty"`
+	Comment string    `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Message string    `json:"message" yaml:"message"`
+	URL     string    `json:"url" yaml:"url"`
+	Time    time.Time `json:"time" yaml:"time"`
+}
+
+// watchQuerySnapshot is the state of an issue that we report changes for
+type watchQuerySnapshot struct {
+	Summary  string
+	Status   string
+	Assignee string
+	Comments jiradata.Comments
+	Total    int
+}
+
+func CmdWatchQueryRegistry() *jiracli.CommandRegistryEntry {
+	opts := WatchQueryOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("watch-query"),
+		},
+		Interval: time.Minute,
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Poll a query and report new issues, status, assignee and comment changes",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdWatchQueryUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdWatchQuery(o, globals, &opts)
+		},
+	}
+}
+
+func CmdWatchQueryUsage(cmd *kingpin.CmdClause, opts *WatchQueryOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to watch").Short('q').StringVar(&opts.Query)
+	cmd.Flag("interval", "How often to poll the query").Short('i').DurationVar(&opts.Interval)
+	cmd.Flag("json", "Print each event as a line of JSON instead of using the template").BoolVar(&opts.JSON)
+	cmd.Flag("notify", "Send a desktop notification for each event with notify-send").BoolVar(&opts.Notify)
+	cmd.Flag("hook", "Shell command to run for each event, the event JSON is sent on stdin").StringVar(&opts.Hook)
+	cmd.Flag("initial", "Report the issues found by the first poll as new").BoolVar(&opts.Initial)
+	return nil
+}
+
+// CmdWatchQuery will poll the query until interrupted, comparing the results
+// with the previous poll and emitting an event for each change.
+func CmdWatchQuery(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WatchQueryOptions) error {
+	if opts.Interval < time.Second {
+		return jiracli.CliError(fmt.Errorf("--interval must be at least 1s, got %s", opts.Interval))
+	}
+	query := opts.Query
+	if query == "" {
+		query = globals.DefaultQuery.Value
+	}
+	if query == "" {
+		query = jiracli.DefaultIssueQuery
+	}
+
+	var previous map[string]*watchQuerySnapshot
+	for {
+		current, err := watchQueryPoll(o, globals, query)
+		if err != nil {
+			if previous == nil {
+				return err
+			}
+			// keep watching through network blips, we will report the
+			// changes on the next successful poll
+			log.Errorf("%s", err)
+		} else {
+			for _, event := range watchQueryDiff(previous, current, opts.Initial) {
+				event.URL = jira.URLJoin(globals.Endpoint.Value, "browse", event.Issue)
+				if err := watchQueryEmit(globals, opts, event); err != nil {
+					return err
+				}
+			}
+			previous = current
+		}
+		time.Sleep(opts.Interval)
+	}
+}
+
+func watchQueryPoll(o *oreo.Client, globals *jiracli.GlobalOptions, query string) (map[string]*watchQuerySnapshot, error) {
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:       query,
+		QueryFields: "status,assignee,comment",
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := map[string]*watchQuerySnapshot{}
+	for _, issue := range results.Issues {
+		snapshot := &watchQuerySnapshot{}
+		snapshot.Summary, _ = issue.Fields["summary"].(string)
+		if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+			snapshot.Status, _ = status["name"].(string)
+		}
+		snapshot.Assignee = fieldUserName(issue.Fields["assignee"])
+		// round trip the comments so we can use the jiradata types
+		if content, err := json.Marshal(issue.Fields["comment"]); err == nil {
+			comments := jiradata.CommentsWithPagination{}
+			if err := json.Unmarshal(content, &comments); err == nil {
+				snapshot.Comments = comments.Comments
+				snapshot.Total = comments.Total
+				if snapshot.Total < len(comments.Comments) {
+					snapshot.Total = len(comments.Comments)
+				}
+			}
+		}
+		snapshots[issue.Key] = snapshot
+	}
+	return snapshots, nil
+}
+
+// watchQueryDiff returns the events for the changes between the snapshots,
+// sorted by issue key.  On the first poll previous is nil and the issues are
+// only reported as new when initial is set.
+func watchQueryDiff(previous, current map[string]*watchQuerySnapshot, initial bool) []*WatchQueryEvent {
+	if previous == nil && !initial {
+		return []*WatchQueryEvent{}
+	}
+	keys := []string{}
+	for key := range current {
+		keys = append(keys, key)
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	now := time.Now()
+	events := []*WatchQueryEvent{}
+	for _, key := range keys {
+		prev, cur := previous[key], current[key]
+		switch {
+		case prev == nil:
+			events = append(events, &WatchQueryEvent{Type: "new", Issue: key, Summary: cur.Summary, To: cur.Status, Message: fmt.Sprintf("new issue in %s", cur.Status), Time: now})
+			continue
+		case cur == nil:
+			events = append(events, &WatchQueryEvent{Type: "removed", Issue: key, Summary: prev.Summary, From: prev.Status, Message: "no longer matches the query", Time: now})
+			continue
+		}
+		if prev.Status != cur.Status {
+			events = append(events, &WatchQueryEvent{Type: "status", Issue: key, Summary: cur.Summary, From: prev.Status, To: cur.Status, Message: fmt.Sprintf("status changed from %s to %s", prev.Status, cur.Status), Time: now})
+		}
+		if prev.Assignee != cur.Assignee {
+			message := fmt.Sprintf("assigned to %s", cur.Assignee)
+			if cur.Assignee == "" {
+				message = fmt.Sprintf("unassigned from %s", prev.Assignee)
+			}
+			events = append(events, &WatchQueryEvent{Type: "assignee", Issue: key, Summary: cur.Summary, From: prev.Assignee, To: cur.Assignee, Message: message, Time: now})
+		}
+		if cur.Total > prev.Total {
+			// the search only returns the most recent comments, so report
+			// the ones we have not seen that are still in the page
+			added := cur.Total - prev.Total
+			if added > len(cur.Comments) {
+				added = len(cur.Comments)
+			}
+			for _, comment := range cur.Comments[len(cur.Comments)-added:] {
+				author := ""
+				if comment.Author != nil {
+					author = comment.Author.DisplayName
+					if author == "" {
+						author = comment.Author.Name
+					}
+				}
+				line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(comment.Body), "\n", 2)[0])
+				events = append(events, &WatchQueryEvent{Type: "comment", Issue: key, Summary: cur.Summary, Author: author, Comment: comment.Body, Message: fmt.Sprintf("comment from %s: %s", author, line), Time: now})
+			}
+		}
+	}
+	return events
+}
+
+func watchQueryEmit(globals *jiracli.GlobalOptions, opts *WatchQueryOptions, event *WatchQueryEvent) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		if opts.JSON {
+			fmt.Printf("%s\n", encoded)
+		} else if err := jiracli.RunTemplate(opts.Template.Value, event, nil); err != nil {
+			return err
+		}
+	}
+
+	if opts.Notify {
+		cmd := exec.Command("notify-send", "--app-name=jira", fmt.Sprintf("%s: %s", event.Issue, event.Summary), event.Message)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			log.Errorf("notify-send failed: %s: %s", err, bytes.TrimSpace(out))
+		}
+	}
+
+	if opts.Hook != "" {
+		cmd := exec.Command("sh", "-c", opts.Hook)
+		cmd.Stdin = bytes.NewReader(encoded)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		cmd.Env = append(os.Environ(),
+			"JIRA_EVENT="+event.Type,
+			"JIRA_ISSUE="+event.Issue,
+			"JIRA_MESSAGE="+event.Message,
+			"JIRA_URL="+event.URL,
+		)
+		if err := cmd.Run(); err != nil {
+			log.Errorf("hook failed for %s %s event: %s", event.Issue, event.Type, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchQueryDiff(t *testing.T) {
+	comment := func(author, body string) *jiradata.Comment {
+		return &jiradata.Comment{Author: &jiradata.User{DisplayName: author}, Body: body}
+	}
+	snapshot := func(status, assignee string, total int, comments ...*jiradata.Comment) *watchQuerySnapshot {
+		return &watchQuerySnapshot{Summary: "Fix it", Status: status, Assignee: assignee, Comments: comments, Total: total}
+	}
+	current := map[string]*watchQuerySnapshot{
+		"TEST-1": snapshot("To Do", "Alice", 0),
+		"TEST-2": snapshot("Done", "", 0),
+	}
+
+	for name, test := range map[string]struct {
+		previous map[string]*watchQuerySnapshot
+		current  map[string]*watchQuerySnapshot
+		initial  bool
+		expected []string
+	}{
+		"first poll": {
+			current:  current,
+			expected: []string{},
+		},
+		"first poll with initial": {
+			current:  current,
+			initial:  true,
+			expected: []string{"new TEST-1: new issue in To Do", "new TEST-2: new issue in Done"},
+		},
+		"unchanged": {
+			previous: current,
+			current:  current,
+			expected: []string{},
+		},
+		"new and removed": {
+			previous: map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 0), "TEST-3": snapshot("In Progress", "", 0)},
+			current:  current,
+			expected: []string{"new TEST-2: new issue in Done", "removed TEST-3: no longer matches the query"},
+		},
+		"status and assignee": {
+			previous: map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "", 0), "TEST-2": snapshot("In Progress", "Bob", 0)},
+			current:  current,
+			expected: []string{
+				"assignee TEST-1: assigned to Alice",
+				"status TEST-2: status changed from In Progress to Done",
+				"assignee TEST-2: unassigned from Bob",
+			},
+		},
+		"new comments": {
+			previous: map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 1, comment("Alice", "first"))},
+			current:  map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 3, comment("Alice", "first"), comment("Bob", "second"), comment("Carol", "\n third\nmore"))},
+			expected: []string{"comment TEST-1: comment from Bob: second", "comment TEST-1: comment from Carol: third"},
+		},
+		"more comments than the page": {
+			previous: map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 1, comment("Alice", "first"))},
+			current:  map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 10, comment("Bob", "ninth"), comment("Carol", "tenth"))},
+			expected: []string{"comment TEST-1: comment from Bob: ninth", "comment TEST-1: comment from Carol: tenth"},
+		},
+		"deleted comment": {
+			previous: map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 2, comment("Alice", "first"), comment("Bob", "second"))},
+			current:  map[string]*watchQuerySnapshot{"TEST-1": snapshot("To Do", "Alice", 1, comment("Alice", "first"))},
+			expected: []string{},
+		},
+	} {
+		events := []string{}
+		for _, event := range watchQueryDiff(test.previous, test.current, test.initial) {
+			events = append(events, event.Type+" "+event.Issue+": "+event.Message)
+		}
+		assert.Equal(t, test.expected, events, name)
+	}
+
+	// the events carry the values that changed for the templates and hooks
+	events := watchQueryDiff(
+		map[string]*watchQuerySnapshot{"TEST-2": snapshot("In Progress", "Bob", 0)},
+		map[string]*watchQuerySnapshot{"TEST-2": snapshot("Done", "Bob", 1, comment("Bob", "done now"))},
+		false,
+	)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, WatchQueryEvent{Type: "status", Issue: "TEST-2", Summary: "Fix it", From: "In Progress", To: "Done", Message: "status changed from In Progress to Done", Time: events[0].Time}, *events[0])
+		assert.Equal(t, WatchQueryEvent{Type: "comment", Issue: "TEST-2", Summary: "Fix it", Author: "Bob", Comment: "done now", Message: "comment from Bob: done now", Time: events[1].Time}, *events[1])
+	}
+}
@@ -45,7 +45,7 @@ func CmdWorklogAddUsage(cmd *kingpin.CmdClause, opts *WorklogAddOptions) error {
 	cmd.Flag("comment", "Comment message for worklog").Short('m').StringVar(&opts.Comment)
 	cmd.Flag("time-spent", "Time spent working on issue").Short('T').StringVar(&opts.TimeSpent)
 	cmd.Flag("started", "Time you started work").Short('S').StringVar(&opts.Started)
-	cmd.Arg("ISSUE", "issue id to fetch worklogs").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue id to fetch worklogs", &opts.Issue)
 	return nil
 }
 
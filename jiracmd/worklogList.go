@@ -38,7 +38,7 @@ func CmdWorklogListUsage(cmd *kingpin.CmdClause, opts *WorklogListOptions) error
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
-	cmd.Arg("ISSUE", "issue id to fetch worklogs").Required().StringVar(&opts.Issue)
+	jiracli.IssueArgUsage(cmd, "issue id to fetch worklogs", &opts.Issue)
 	return nil
 }
 
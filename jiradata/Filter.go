@@ -0,0 +1,96 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/Filters.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// Filter defined from schema:
+// {
+//   "title": "Filter",
+//   "type": "object",
+//   "properties": {
+//     "description": {
+//       "title": "description",
+//       "type": "string"
+//     },
+//     "favourite": {
+//       "title": "favourite",
+//       "type": "boolean"
+//     },
+//     "id": {
+//       "title": "id",
+//       "type": "string"
+//     },
+//     "jql": {
+//       "title": "jql",
+//       "type": "string"
+//     },
+//     "name": {
+//       "title": "name",
+//       "type": "string"
+//     },
+//     "owner": {
+//       "title": "User",
+//       "type": "object",
+//       "properties": {
+//         "accountId": {
+//           "title": "accountId",
+//           "type": "string"
+//         },
+//         "active": {
+//           "title": "active",
+//           "type": "boolean"
+//         },
+//         "displayName": {
+//           "title": "displayName",
+//           "type": "string"
+//         },
+//         "emailAddress": {
+//           "title": "emailAddress",
+//           "type": "string"
+//         },
+//         "key": {
+//           "title": "key",
+//           "type": "string"
+//         },
+//         "name": {
+//           "title": "name",
+//           "type": "string"
+//         },
+//         "self": {
+//           "title": "self",
+//           "type": "string"
+//         }
+//       }
+//     },
+//     "searchUrl": {
+//       "title": "searchUrl",
+//       "type": "string"
+//     },
+//     "self": {
+//       "title": "self",
+//       "type": "string"
+//     },
+//     "viewUrl": {
+//       "title": "viewUrl",
+//       "type": "string"
+//     }
+//   }
+// }
+type Filter struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Favourite   bool   `json:"favourite,omitempty" yaml:"favourite,omitempty"`
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	JQL         string `json:"jql,omitempty" yaml:"jql,omitempty"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Owner       *User  `json:"owner,omitempty" yaml:"owner,omitempty"`
+	SearchURL   string `json:"searchUrl,omitempty" yaml:"searchUrl,omitempty"`
+	Self        string `json:"self,omitempty" yaml:"self,omitempty"`
+	ViewURL     string `json:"viewUrl,omitempty" yaml:"viewUrl,omitempty"`
+}
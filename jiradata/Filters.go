@@ -0,0 +1,90 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/Filters.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// Filters defined from schema:
+// {
+//   "title": "Filters",
+//   "type": "array",
+//   "items": {
+//     "title": "Filter",
+//     "type": "object",
+//     "properties": {
+//       "description": {
+//         "title": "description",
+//         "type": "string"
+//       },
+//       "favourite": {
+//         "title": "favourite",
+//         "type": "boolean"
+//       },
+//       "id": {
+//         "title": "id",
+//         "type": "string"
+//       },
+//       "jql": {
+//         "title": "jql",
+//         "type": "string"
+//       },
+//       "name": {
+//         "title": "name",
+//         "type": "string"
+//       },
+//       "owner": {
+//         "title": "User",
+//         "type": "object",
+//         "properties": {
+//           "accountId": {
+//             "title": "accountId",
+//             "type": "string"
+//           },
+//           "active": {
+//             "title": "active",
+//             "type": "boolean"
+//           },
+//           "displayName": {
+//             "title": "displayName",
+//             "type": "string"
+//           },
+//           "emailAddress": {
+//             "title": "emailAddress",
+//             "type": "string"
+//           },
+//           "key": {
+//             "title": "key",
+//             "type": "string"
+//           },
+//           "name": {
+//             "title": "name",
+//             "type": "string"
+//           },
+//           "self": {
+//             "title": "self",
+//             "type": "string"
+//           }
+//         }
+//       },
+//       "searchUrl": {
+//         "title": "searchUrl",
+//         "type": "string"
+//       },
+//       "self": {
+//         "title": "self",
+//         "type": "string"
+//       },
+//       "viewUrl": {
+//         "title": "viewUrl",
+//         "type": "string"
+//       }
+//     }
+//   }
+// }
+type Filters []*Filter
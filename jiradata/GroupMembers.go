@@ -0,0 +1,164 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/GroupMembers.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// GroupMembers defined from schema:
+// {
+//   "title": "GroupMembers",
+//   "type": "object",
+//   "properties": {
+//     "isLast": {
+//       "title": "isLast",
+//       "type": "boolean"
+//     },
+//     "maxResults": {
+//       "title": "maxResults",
+//       "type": "integer"
+//     },
+//     "nextPage": {
+//       "title": "nextPage",
+//       "type": "string"
+//     },
+//     "self": {
+//       "title": "self",
+//       "type": "string"
+//     },
+//     "startAt": {
+//       "title": "startAt",
+//       "type": "integer"
+//     },
+//     "total": {
+//       "title": "total",
+//       "type": "integer"
+//     },
+//     "values": {
+//       "type": "array",
+//       "items": {
+//         "title": "User",
+//         "type": "object",
+//         "properties": {
+//           "accountId": {
+//             "title": "accountId",
+//             "type": "string"
+//           },
+//           "active": {
+//             "title": "active",
+//             "type": "boolean"
+//           },
+//           "applicationRoles": {
+//             "title": "Simple List Wrapper",
+//             "type": "object",
+//             "properties": {
+//               "items": {
+//                 "type": "array",
+//                 "items": {
+//                   "title": "Group",
+//                   "type": "object",
+//                   "properties": {
+//                     "name": {
+//                       "type": "string"
+//                     },
+//                     "self": {
+//                       "type": "string"
+//                     }
+//                   }
+//                 }
+//               },
+//               "max-results": {
+//                 "type": "integer"
+//               },
+//               "size": {
+//                 "type": "integer"
+//               }
+//             }
+//           },
+//           "avatarUrls": {
+//             "title": "avatarUrls",
+//             "type": "object",
+//             "patternProperties": {
+//               ".+": {
+//                 "type": "string"
+//               }
+//             }
+//           },
+//           "displayName": {
+//             "title": "displayName",
+//             "type": "string"
+//           },
+//           "emailAddress": {
+//             "title": "emailAddress",
+//             "type": "string"
+//           },
+//           "expand": {
+//             "title": "expand",
+//             "type": "string"
+//           },
+//           "groups": {
+//             "title": "Simple List Wrapper",
+//             "type": "object",
+//             "properties": {
+//               "items": {
+//                 "type": "array",
+//                 "items": {
+//                   "title": "Group",
+//                   "type": "object",
+//                   "properties": {
+//                     "name": {
+//                       "type": "string"
+//                     },
+//                     "self": {
+//                       "type": "string"
+//                     }
+//                   }
+//                 }
+//               },
+//               "max-results": {
+//                 "type": "integer"
+//               },
+//               "size": {
+//                 "type": "integer"
+//               }
+//             }
+//           },
+//           "key": {
+//             "title": "key",
+//             "type": "string"
+//           },
+//           "locale": {
+//             "title": "locale",
+//             "type": "string"
+//           },
+//           "name": {
+//             "title": "name",
+//             "type": "string"
+//           },
+//           "self": {
+//             "title": "self",
+//             "type": "string"
+//           },
+//           "timeZone": {
+//             "title": "timeZone",
+//             "type": "string"
+//           }
+//         }
+//       }
+//     }
+//   }
+// }
+type GroupMembers struct {
+	IsLast     bool    `json:"isLast,omitempty" yaml:"isLast,omitempty"`
+	MaxResults int     `json:"maxResults,omitempty" yaml:"maxResults,omitempty"`
+	NextPage   string  `json:"nextPage,omitempty" yaml:"nextPage,omitempty"`
+	Self       string  `json:"self,omitempty" yaml:"self,omitempty"`
+	StartAt    int     `json:"startAt,omitempty" yaml:"startAt,omitempty"`
+	Total      int     `json:"total,omitempty" yaml:"total,omitempty"`
+	Values     []*User `json:"values,omitempty" yaml:"values,omitempty"`
+}
@@ -0,0 +1,31 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/PropertyKeys.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// PropertyKey defined from schema:
+// {
+//   "title": "Property Key",
+//   "type": "object",
+//   "properties": {
+//     "key": {
+//       "title": "key",
+//       "type": "string"
+//     },
+//     "self": {
+//       "title": "self",
+//       "type": "string"
+//     }
+//   }
+// }
+type PropertyKey struct {
+	Key  string `json:"key,omitempty" yaml:"key,omitempty"`
+	Self string `json:"self,omitempty" yaml:"self,omitempty"`
+}
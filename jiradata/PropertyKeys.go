@@ -0,0 +1,39 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/PropertyKeys.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// PropertyKeys defined from schema:
+// {
+//   "title": "Property Keys",
+//   "type": "object",
+//   "properties": {
+//     "keys": {
+//       "type": "array",
+//       "items": {
+//         "title": "Property Key",
+//         "type": "object",
+//         "properties": {
+//           "key": {
+//             "title": "key",
+//             "type": "string"
+//           },
+//           "self": {
+//             "title": "self",
+//             "type": "string"
+//           }
+//         }
+//       }
+//     }
+//   }
+// }
+type PropertyKeys struct {
+	Keys []*PropertyKey `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
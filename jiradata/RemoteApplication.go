@@ -0,0 +1,31 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/RemoteIssueLinks.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// RemoteApplication defined from schema:
+// {
+//   "title": "Remote Application",
+//   "type": "object",
+//   "properties": {
+//     "name": {
+//       "title": "name",
+//       "type": "string"
+//     },
+//     "type": {
+//       "title": "type",
+//       "type": "string"
+//     }
+//   }
+// }
+type RemoteApplication struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
@@ -0,0 +1,36 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/RemoteIssueLinks.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// RemoteIcon defined from schema:
+// {
+//   "title": "Remote Icon",
+//   "type": "object",
+//   "properties": {
+//     "link": {
+//       "title": "link",
+//       "type": "string"
+//     },
+//     "title": {
+//       "title": "title",
+//       "type": "string"
+//     },
+//     "url16x16": {
+//       "title": "url16x16",
+//       "type": "string"
+//     }
+//   }
+// }
+type RemoteIcon struct {
+	Link     string `json:"link,omitempty" yaml:"link,omitempty"`
+	Title    string `json:"title,omitempty" yaml:"title,omitempty"`
+	Url16x16 string `json:"url16x16,omitempty" yaml:"url16x16,omitempty"`
+}
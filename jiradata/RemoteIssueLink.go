@@ -0,0 +1,121 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/RemoteIssueLinks.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// RemoteIssueLink defined from schema:
+// {
+//   "title": "Remote Issue Link",
+//   "type": "object",
+//   "properties": {
+//     "application": {
+//       "title": "Remote Application",
+//       "type": "object",
+//       "properties": {
+//         "name": {
+//           "title": "name",
+//           "type": "string"
+//         },
+//         "type": {
+//           "title": "type",
+//           "type": "string"
+//         }
+//       }
+//     },
+//     "globalId": {
+//       "title": "globalId",
+//       "type": "string"
+//     },
+//     "id": {
+//       "title": "id",
+//       "type": "integer"
+//     },
+//     "object": {
+//       "title": "Remote Object",
+//       "type": "object",
+//       "properties": {
+//         "icon": {
+//           "title": "Remote Icon",
+//           "type": "object",
+//           "properties": {
+//             "link": {
+//               "title": "link",
+//               "type": "string"
+//             },
+//             "title": {
+//               "title": "title",
+//               "type": "string"
+//             },
+//             "url16x16": {
+//               "title": "url16x16",
+//               "type": "string"
+//             }
+//           }
+//         },
+//         "status": {
+//           "title": "Remote Status",
+//           "type": "object",
+//           "properties": {
+//             "icon": {
+//               "title": "Remote Icon",
+//               "type": "object",
+//               "properties": {
+//                 "link": {
+//                   "title": "link",
+//                   "type": "string"
+//                 },
+//                 "title": {
+//                   "title": "title",
+//                   "type": "string"
+//                 },
+//                 "url16x16": {
+//                   "title": "url16x16",
+//                   "type": "string"
+//                 }
+//               }
+//             },
+//             "resolved": {
+//               "title": "resolved",
+//               "type": "boolean"
+//             }
+//           }
+//         },
+//         "summary": {
+//           "title": "summary",
+//           "type": "string"
+//         },
+//         "title": {
+//           "title": "title",
+//           "type": "string"
+//         },
+//         "url": {
+//           "title": "url",
+//           "type": "string"
+//         }
+//       }
+//     },
+//     "relationship": {
+//       "title": "relationship",
+//       "type": "string"
+//     },
+//     "self": {
+//       "title": "self",
+//       "type": "string"
+//     }
+//   }
+// }
+type RemoteIssueLink struct {
+	Application  *RemoteApplication `json:"application,omitempty" yaml:"application,omitempty"`
+	GlobalID     string             `json:"globalId,omitempty" yaml:"globalId,omitempty"`
+	ID           int                `json:"id,omitempty" yaml:"id,omitempty"`
+	Object       *RemoteObject      `json:"object,omitempty" yaml:"object,omitempty"`
+	Relationship string             `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	Self         string             `json:"self,omitempty" yaml:"self,omitempty"`
+}
@@ -0,0 +1,84 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/RemoteIssueLinks.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// RemoteObject defined from schema:
+// {
+//   "title": "Remote Object",
+//   "type": "object",
+//   "properties": {
+//     "icon": {
+//       "title": "Remote Icon",
+//       "type": "object",
+//       "properties": {
+//         "link": {
+//           "title": "link",
+//           "type": "string"
+//         },
+//         "title": {
+//           "title": "title",
+//           "type": "string"
+//         },
+//         "url16x16": {
+//           "title": "url16x16",
+//           "type": "string"
+//         }
+//       }
+//     },
+//     "status": {
+//       "title": "Remote Status",
+//       "type": "object",
+//       "properties": {
+//         "icon": {
+//           "title": "Remote Icon",
+//           "type": "object",
+//           "properties": {
+//             "link": {
+//               "title": "link",
+//               "type": "string"
+//             },
+//             "title": {
+//               "title": "title",
+//               "type": "string"
+//             },
+//             "url16x16": {
+//               "title": "url16x16",
+//               "type": "string"
+//             }
+//           }
+//         },
+//         "resolved": {
+//           "title": "resolved",
+//           "type": "boolean"
+//         }
+//       }
+//     },
+//     "summary": {
+//       "title": "summary",
+//       "type": "string"
+//     },
+//     "title": {
+//       "title": "title",
+//       "type": "string"
+//     },
+//     "url": {
+//       "title": "url",
+//       "type": "string"
+//     }
+//   }
+// }
+type RemoteObject struct {
+	Icon    *RemoteIcon   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Status  *RemoteStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	Summary string        `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Title   string        `json:"title,omitempty" yaml:"title,omitempty"`
+	URL     string        `json:"url,omitempty" yaml:"url,omitempty"`
+}
@@ -0,0 +1,45 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/RemoteIssueLinks.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// RemoteStatus defined from schema:
+// {
+//   "title": "Remote Status",
+//   "type": "object",
+//   "properties": {
+//     "icon": {
+//       "title": "Remote Icon",
+//       "type": "object",
+//       "properties": {
+//         "link": {
+//           "title": "link",
+//           "type": "string"
+//         },
+//         "title": {
+//           "title": "title",
+//           "type": "string"
+//         },
+//         "url16x16": {
+//           "title": "url16x16",
+//           "type": "string"
+//         }
+//       }
+//     },
+//     "resolved": {
+//       "title": "resolved",
+//       "type": "boolean"
+//     }
+//   }
+// }
+type RemoteStatus struct {
+	Icon     *RemoteIcon `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Resolved bool        `json:"resolved,omitempty" yaml:"resolved,omitempty"`
+}
@@ -0,0 +1,149 @@
+package jiradata
+
+/////////////////////////////////////////////////////////////////////////
+// This Code is Generated by SlipScheme Project:
+// https://github.com/coryb/slipscheme
+//
+// Generated with command:
+// slipscheme -dir jiradata -pkg jiradata -overwrite schemas/Watchers.json
+/////////////////////////////////////////////////////////////////////////
+//                            DO NOT EDIT                              //
+/////////////////////////////////////////////////////////////////////////
+
+// Watchers defined from schema:
+// {
+//   "title": "Watchers",
+//   "type": "object",
+//   "properties": {
+//     "isWatching": {
+//       "title": "isWatching",
+//       "type": "boolean"
+//     },
+//     "self": {
+//       "title": "self",
+//       "type": "string"
+//     },
+//     "watchCount": {
+//       "title": "watchCount",
+//       "type": "integer"
+//     },
+//     "watchers": {
+//       "type": "array",
+//       "items": {
+//         "title": "User",
+//         "type": "object",
+//         "properties": {
+//           "accountId": {
+//             "title": "accountId",
+//             "type": "string"
+//           },
+//           "active": {
+//             "title": "active",
+//             "type": "boolean"
+//           },
+//           "applicationRoles": {
+//             "title": "Simple List Wrapper",
+//             "type": "object",
+//             "properties": {
+//               "items": {
+//                 "type": "array",
+//                 "items": {
+//                   "title": "Group",
+//                   "type": "object",
+//                   "properties": {
+//                     "name": {
+//                       "type": "string"
+//                     },
+//                     "self": {
+//                       "type": "string"
+//                     }
+//                   }
+//                 }
+//               },
+//               "max-results": {
+//                 "type": "integer"
+//               },
+//               "size": {
+//                 "type": "integer"
+//               }
+//             }
+//           },
+//           "avatarUrls": {
+//             "title": "avatarUrls",
+//             "type": "object",
+//             "patternProperties": {
+//               ".+": {
+//                 "type": "string"
+//               }
+//             }
+//           },
+//           "displayName": {
+//             "title": "displayName",
+//             "type": "string"
+//           },
+//           "emailAddress": {
+//             "title": "emailAddress",
+//             "type": "string"
+//           },
+//           "expand": {
+//             "title": "expand",
+//             "type": "string"
+//           },
+//           "groups": {
+//             "title": "Simple List Wrapper",
+//             "type": "object",
+//             "properties": {
+//               "items": {
+//                 "type": "array",
+//                 "items": {
+//                   "title": "Group",
+//                   "type": "object",
+//                   "properties": {
+//                     "name": {
+//                       "type": "string"
+//                     },
+//                     "self": {
+//                       "type": "string"
+//                     }
+//                   }
+//                 }
+//               },
+//               "max-results": {
+//                 "type": "integer"
+//               },
+//               "size": {
+//                 "type": "integer"
+//               }
+//             }
+//           },
+//           "key": {
+//             "title": "key",
+//             "type": "string"
+//           },
+//           "locale": {
+//             "title": "locale",
+//             "type": "string"
+//           },
+//           "name": {
+//             "title": "name",
+//             "type": "string"
+//           },
+//           "self": {
+//             "title": "self",
+//             "type": "string"
+//           },
+//           "timeZone": {
+//             "title": "timeZone",
+//             "type": "string"
+//           }
+//         }
+//       }
+//     }
+//   }
+// }
+type Watchers struct {
+	IsWatching bool    `json:"isWatching,omitempty" yaml:"isWatching,omitempty"`
+	Self       string  `json:"self,omitempty" yaml:"self,omitempty"`
+	WatchCount int     `json:"watchCount,omitempty" yaml:"watchCount,omitempty"`
+	Watchers   []*User `json:"watchers,omitempty" yaml:"watchers,omitempty"`
+}
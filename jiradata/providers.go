@@ -29,3 +29,11 @@ func (c *Component) ProvideComponent() *Component {
 func (e *EpicIssues) ProvideEpicIssues() *EpicIssues {
 	return e
 }
+
+func (r *RemoteIssueLink) ProvideRemoteIssueLink() *RemoteIssueLink {
+	return r
+}
+
+func (f *Filter) ProvideFilter() *Filter {
+	return f
+}
@@ -0,0 +1,714 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// This is a small JQL interpreter used to search the OfflineStore.  It
+// supports AND, OR, NOT and parentheses with the =, !=, ~, !~, <, <=, >, >=,
+// IN, NOT IN, IS and IS NOT operators, the currentUser() and now() functions
+// and ORDER BY.  Anything else is reported as unsupported rather than
+// silently matching the wrong issues.
+
+type jqlEnv struct {
+	fields []jiradata.Field
+	myself map[string]interface{}
+}
+
+// JQLFilter matches issues locally with the same JQL subset that is
+// supported offline, it is used to filter the issues sent with webhooks.
+type JQLFilter struct {
+	query *jqlQuery
+	env   *jqlEnv
+}
+
+// NewJQLFilter will parse the query, the fields are used to find custom
+// fields by name and may be nil.  ORDER BY is ignored.
+func NewJQLFilter(query string, fields []jiradata.Field) (*JQLFilter, error) {
+	q, err := parseJQL(query)
+	if err != nil {
+		return nil, err
+	}
+	return &JQLFilter{
+		query: q,
+		env:   &jqlEnv{fields: fields},
+	}, nil
+}
+
+// Match returns true if the issue matches the filter.
+func (f *JQLFilter) Match(issue *jiradata.Issue) (bool, error) {
+	return f.query.match(f.env, &OfflineIssue{Issue: issue})
+}
+
+type jqlQuery struct {
+	where jqlExpr
+	order []jqlOrder
+}
+
+type jqlOrder struct {
+	field string
+	desc  bool
+}
+
+type jqlExpr interface {
+	match(env *jqlEnv, issue *OfflineIssue) (bool, error)
+}
+
+type jqlAnd []jqlExpr
+type jqlOr []jqlExpr
+type jqlNot struct{ expr jqlExpr }
+
+type jqlClause struct {
+	field  string
+	op     string
+	values []jqlValue
+}
+
+type jqlValue struct {
+	text     string
+	function string
+	empty    bool
+}
+
+func (e jqlAnd) match(env *jqlEnv, issue *OfflineIssue) (bool, error) {
+	for _, expr := range e {
+		if ok, err := expr.match(env, issue); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (e jqlOr) match(env *jqlEnv, issue *OfflineIssue) (bool, error) {
+	for _, expr := range e {
+		if ok, err := expr.match(env, issue); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (e jqlNot) match(env *jqlEnv, issue *OfflineIssue) (bool, error) {
+	ok, err := e.expr.match(env, issue)
+	return !ok, err
+}
+
+func (q *jqlQuery) match(env *jqlEnv, issue *OfflineIssue) (bool, error) {
+	if q.where == nil {
+		return true, nil
+	}
+	return q.where.match(env, issue)
+}
+
+func (q *jqlQuery) sort(env *jqlEnv, issues []*OfflineIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		for _, order := range q.order {
+			a, b := jqlSortValue(env, issues[i], order.field), jqlSortValue(env, issues[j], order.field)
+			if a == b {
+				continue
+			}
+			less := a < b
+			if af, err := strconv.ParseFloat(a, 64); err == nil {
+				if bf, err := strconv.ParseFloat(b, 64); err == nil {
+					less = af < bf
+				}
+			}
+			if order.desc {
+				return !less
+			}
+			return less
+		}
+		return false
+	})
+}
+
+func (c *jqlClause) match(env *jqlEnv, issue *OfflineIssue) (bool, error) {
+	actual := jqlFieldValues(env, issue, c.field)
+
+	expected := []string{}
+	for _, value := range c.values {
+		switch {
+		case value.empty:
+		case strings.EqualFold(value.function, "currentUser"):
+			expected = append(expected, jqlUserValues(env.myself)...)
+		case strings.EqualFold(value.function, "now"):
+			expected = append(expected, time.Now().Format(jqlTimeLayout))
+		case value.function != "":
+			return false, fmt.Errorf("JQL function %s() is not supported offline", value.function)
+		default:
+			expected = append(expected, value.text)
+		}
+	}
+
+	switch c.op {
+	case "is", "is not":
+		if len(c.values) != 1 || !c.values[0].empty {
+			return false, fmt.Errorf("%s %s must be followed by EMPTY", c.field, strings.ToUpper(c.op))
+		}
+		return (len(actual) == 0) == (c.op == "is"), nil
+	case "=", "in":
+		if c.values[0].empty && c.op == "=" {
+			return len(actual) == 0, nil
+		}
+		return jqlAnyEqual(c.field, actual, expected), nil
+	case "!=", "not in":
+		if c.values[0].empty && c.op == "!=" {
+			return len(actual) > 0, nil
+		}
+		return !jqlAnyEqual(c.field, actual, expected), nil
+	case "~", "!~":
+		found := false
+		for _, a := range actual {
+			for _, e := range expected {
+				if strings.Contains(strings.ToLower(a), strings.ToLower(strings.Trim(e, "*"))) {
+					found = true
+				}
+			}
+		}
+		return found == (c.op == "~"), nil
+	case "<", "<=", ">", ">=":
+		if len(expected) != 1 {
+			return false, fmt.Errorf("%s %s requires a single value", c.field, c.op)
+		}
+		for _, a := range actual {
+			cmp, err := jqlCompare(a, expected[0])
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case c.op == "<" && cmp < 0, c.op == "<=" && cmp <= 0, c.op == ">" && cmp > 0, c.op == ">=" && cmp >= 0:
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("JQL operator %q is not supported offline", c.op)
+}
+
+func jqlAnyEqual(field string, actual, expected []string) bool {
+	for _, e := range expected {
+		// "resolution = unresolved" is how you find issues without a resolution
+		if strings.EqualFold(field, "resolution") && strings.EqualFold(e, "unresolved") && len(actual) == 0 {
+			return true
+		}
+		for _, a := range actual {
+			if strings.EqualFold(a, e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const jqlTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+var jqlRelativeTime = regexp.MustCompile(`^([-+]?)(\d+)([wdhm])$`)
+
+// jqlTime will parse the timestamps from issues along with the absolute and
+// relative dates allowed in JQL.
+func jqlTime(value string) (time.Time, bool) {
+	for _, layout := range []string{jqlTimeLayout, "2006-01-02 15:04", "2006/01/02 15:04", "2006-01-02", "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	if match := jqlRelativeTime.FindStringSubmatch(strings.ToLower(value)); match != nil {
+		n, _ := strconv.Atoi(match[2])
+		unit := map[string]time.Duration{"w": 7 * 24 * time.Hour, "d": 24 * time.Hour, "h": time.Hour, "m": time.Minute}[match[3]]
+		delta := time.Duration(n) * unit
+		if match[1] == "-" {
+			delta = -delta
+		}
+		return time.Now().Add(delta), true
+	}
+	return time.Time{}, false
+}
+
+func jqlCompare(a, b string) (int, error) {
+	if at, ok := jqlTime(a); ok {
+		bt, ok := jqlTime(b)
+		if !ok {
+			return 0, fmt.Errorf("Unable to parse %q as a date", b)
+		}
+		switch {
+		case at.Before(bt):
+			return -1, nil
+		case at.After(bt):
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if af, err := strconv.ParseFloat(a, 64); err == nil {
+		if bf, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case af < bf:
+				return -1, nil
+			case af > bf:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b)), nil
+}
+
+// jqlFieldAliases maps the JQL field names to the issue field ids
+var jqlFieldAliases = map[string]string{
+	"affectedversion": "versions",
+	"component":       "components",
+	"due":             "duedate",
+	"fixversion":      "fixVersions",
+	"resolved":        "resolutiondate",
+	"type":            "issuetype",
+}
+
+var jqlCustomField = regexp.MustCompile(`^cf\[(\d+)\]$`)
+
+// jqlField returns the issue field by id, ignoring case since JQL is case
+// insensitive.
+func jqlField(issue *OfflineIssue, id string) interface{} {
+	if value, ok := issue.Issue.Fields[id]; ok {
+		return value
+	}
+	for key, value := range issue.Issue.Fields {
+		if strings.EqualFold(key, id) {
+			return value
+		}
+	}
+	return nil
+}
+
+func jqlFieldID(env *jqlEnv, field string) string {
+	lower := strings.ToLower(field)
+	if alias, ok := jqlFieldAliases[lower]; ok {
+		return alias
+	}
+	if match := jqlCustomField.FindStringSubmatch(lower); match != nil {
+		return "customfield_" + match[1]
+	}
+	for _, f := range env.fields {
+		if strings.EqualFold(f.ID, field) {
+			return f.ID
+		}
+	}
+	for _, f := range env.fields {
+		if strings.EqualFold(f.Name, field) {
+			return f.ID
+		}
+	}
+	return field
+}
+
+// jqlFieldValues returns all the strings a JQL value could match for the
+// field, for example a status matches by name or id.
+func jqlFieldValues(env *jqlEnv, issue *OfflineIssue, field string) []string {
+	switch strings.ToLower(field) {
+	case "key", "issue", "issuekey":
+		return []string{issue.Issue.Key, issue.Issue.ID}
+	case "id":
+		return []string{issue.Issue.ID}
+	case "project":
+		return jqlValues(issue.Issue.Fields["project"])
+	case "parent":
+		if parent, ok := issue.Issue.Fields["parent"].(map[string]interface{}); ok {
+			return jqlValues(parent["key"])
+		}
+		return nil
+	case "statuscategory":
+		if status, ok := issue.Issue.Fields["status"].(map[string]interface{}); ok {
+			return jqlValues(status["statusCategory"])
+		}
+		return nil
+	case "watcher":
+		if issue.Watchers == nil {
+			return nil
+		}
+		values := []string{}
+		for _, user := range issue.Watchers.Watchers {
+			values = append(values, user.AccountID, user.Name, user.EmailAddress, user.DisplayName, user.Key)
+		}
+		return jqlNonEmpty(values)
+	case "text":
+		values := jqlValues(issue.Issue.Fields["summary"])
+		values = append(values, jqlValues(issue.Issue.Fields["description"])...)
+		if comments, ok := issue.Issue.Fields["comment"].(map[string]interface{}); ok {
+			if list, ok := comments["comments"].([]interface{}); ok {
+				for _, comment := range list {
+					if c, ok := comment.(map[string]interface{}); ok {
+						values = append(values, jqlValues(c["body"])...)
+					}
+				}
+			}
+		}
+		return values
+	}
+	return jqlValues(jqlField(issue, jqlFieldID(env, field)))
+}
+
+func jqlValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			values = append(values, jqlValues(item)...)
+		}
+		return values
+	case map[string]interface{}:
+		// users are the only objects with a displayName
+		if _, ok := v["displayName"]; ok {
+			return jqlUserValues(v)
+		}
+		values := []string{}
+		for _, key := range []string{"key", "name", "value", "id"} {
+			values = append(values, jqlValues(v[key])...)
+		}
+		return values
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+func jqlUserValues(user map[string]interface{}) []string {
+	values := []string{}
+	for _, key := range []string{"accountId", "name", "key", "emailAddress", "displayName"} {
+		values = append(values, jqlValues(user[key])...)
+	}
+	return values
+}
+
+func jqlNonEmpty(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// jqlSortValue returns the value used to ORDER BY the field, priorities sort
+// by id since that is the order they are defined in.
+func jqlSortValue(env *jqlEnv, issue *OfflineIssue, field string) string {
+	switch strings.ToLower(field) {
+	case "key", "issuekey":
+		// sort by project then the issue number
+		parts := strings.SplitN(issue.Issue.Key, "-", 2)
+		if len(parts) == 2 {
+			if n, err := strconv.Atoi(parts[1]); err == nil {
+				return fmt.Sprintf("%s-%010d", parts[0], n)
+			}
+		}
+		return issue.Issue.Key
+	case "rank":
+		return ""
+	}
+	value := jqlField(issue, jqlFieldID(env, field))
+	if m, ok := value.(map[string]interface{}); ok {
+		if strings.EqualFold(field, "priority") {
+			// the highest priority has the lowest id, but should sort last
+			// so "ORDER BY priority DESC" puts it first like Jira does
+			if id, err := strconv.Atoi(fmt.Sprint(m["id"])); err == nil {
+				return strconv.Itoa(-id)
+			}
+		}
+		for _, key := range []string{"displayName", "name", "value", "key"} {
+			if s, ok := m[key].(string); ok {
+				return s
+			}
+		}
+	}
+	values := jqlValues(value)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// parsing
+
+type jqlParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseJQL(query string) (*jqlQuery, error) {
+	tokens, err := jqlTokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &jqlParser{tokens: tokens}
+	q := &jqlQuery{}
+	if p.peek() != "" && !p.peekKeyword("order") {
+		if q.where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peekKeyword("order") {
+		p.next()
+		if !p.peekKeyword("by") {
+			return nil, fmt.Errorf("Expected BY after ORDER in %q", query)
+		}
+		p.next()
+		for {
+			field := p.next()
+			if field == "" {
+				return nil, fmt.Errorf("Expected field after ORDER BY in %q", query)
+			}
+			order := jqlOrder{field: jqlUnquote(field)}
+			if p.peekKeyword("asc") {
+				p.next()
+			} else if p.peekKeyword("desc") {
+				p.next()
+				order.desc = true
+			}
+			q.order = append(q.order, order)
+			if p.peek() != "," {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.peek() != "" {
+		return nil, fmt.Errorf("Unexpected %q in %q", p.peek(), query)
+	}
+	return q, nil
+}
+
+func (p *jqlParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *jqlParser) peekKeyword(keyword string) bool {
+	return strings.EqualFold(p.peek(), keyword)
+}
+
+func (p *jqlParser) next() string {
+	token := p.peek()
+	if token != "" {
+		p.pos++
+	}
+	return token
+}
+
+func (p *jqlParser) parseOr() (jqlExpr, error) {
+	exprs := jqlOr{}
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.peekKeyword("or") && p.peek() != "||" {
+			break
+		}
+		p.next()
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *jqlParser) parseAnd() (jqlExpr, error) {
+	exprs := jqlAnd{}
+	for {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.peekKeyword("and") && p.peek() != "&&" {
+			break
+		}
+		p.next()
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *jqlParser) parseNot() (jqlExpr, error) {
+	if p.peekKeyword("not") || p.peek() == "!" {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return jqlNot{expr}, nil
+	}
+	if p.peek() == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("Missing closing parenthesis in JQL")
+		}
+		return expr, nil
+	}
+	return p.parseClause()
+}
+
+func (p *jqlParser) parseClause() (jqlExpr, error) {
+	field := p.next()
+	if field == "" || strings.ContainsAny(field, "(),") {
+		return nil, fmt.Errorf("Expected field name in JQL, found %q", field)
+	}
+	clause := &jqlClause{field: jqlUnquote(field)}
+
+	op := strings.ToLower(p.next())
+	switch op {
+	case "not":
+		if !p.peekKeyword("in") {
+			return nil, fmt.Errorf("Expected IN after NOT for %s", clause.field)
+		}
+		p.next()
+		op = "not in"
+	case "is":
+		if p.peekKeyword("not") {
+			p.next()
+			op = "is not"
+		}
+	case "=", "!=", "~", "!~", "<", "<=", ">", ">=", "in":
+	case "was", "changed":
+		return nil, fmt.Errorf("JQL history searches (%s) are not supported offline", strings.ToUpper(op))
+	default:
+		return nil, fmt.Errorf("Unsupported JQL operator %q for %s", op, clause.field)
+	}
+	clause.op = op
+
+	if op == "in" || op == "not in" {
+		if p.peek() != "(" {
+			// list functions like membersOf(...) are used without a list
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			if value.function == "" {
+				return nil, fmt.Errorf("Expected ( after %s for %s", strings.ToUpper(op), clause.field)
+			}
+			clause.values = []jqlValue{value}
+			return clause, nil
+		}
+		if p.next() != "(" {
+			return nil, fmt.Errorf("Expected ( after %s for %s", strings.ToUpper(op), clause.field)
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			clause.values = append(clause.values, value)
+			token := p.next()
+			if token == ")" {
+				break
+			} else if token != "," {
+				return nil, fmt.Errorf("Expected , or ) in list for %s", clause.field)
+			}
+		}
+		return clause, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	clause.values = []jqlValue{value}
+	return clause, nil
+}
+
+func (p *jqlParser) parseValue() (jqlValue, error) {
+	token := p.next()
+	if token == "" || token == "(" || token == ")" || token == "," {
+		return jqlValue{}, fmt.Errorf("Expected value in JQL, found %q", token)
+	}
+	if !jqlQuoted(token) {
+		if strings.EqualFold(token, "empty") || strings.EqualFold(token, "null") {
+			return jqlValue{empty: true}, nil
+		}
+		if p.peek() == "(" {
+			p.next()
+			if p.next() != ")" {
+				return jqlValue{}, fmt.Errorf("JQL function arguments are not supported offline: %s()", token)
+			}
+			return jqlValue{function: token}, nil
+		}
+	}
+	return jqlValue{text: jqlUnquote(token)}, nil
+}
+
+func jqlQuoted(token string) bool {
+	return len(token) >= 2 && (token[0] == '"' || token[0] == '\'')
+}
+
+func jqlUnquote(token string) string {
+	if jqlQuoted(token) {
+		token = token[1 : len(token)-1]
+		return strings.NewReplacer(`\"`, `"`, `\'`, `'`, `\\`, `\`).Replace(token)
+	}
+	return token
+}
+
+func jqlTokenize(query string) ([]string, error) {
+	tokens := []string{}
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("Unterminated string in JQL: %s", query)
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j + 1
+		case strings.ContainsRune("(),", r):
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("=!~<>&|", r):
+			j := i + 1
+			if j < len(runes) && strings.ContainsRune("=~&|", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			j := i
+			for ; j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("\"'(),=!~<>&|", runes[j]); j++ {
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		}
+	}
+	return tokens, nil
+}
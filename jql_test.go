@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJQLMatch(t *testing.T) {
+	env := &jqlEnv{
+		fields: []jiradata.Field{{ID: "customfield_10010", Name: "Story Points"}},
+		myself: map[string]interface{}{"accountId": "u1", "displayName": "Alice"},
+	}
+	issue := &OfflineIssue{
+		Issue: &jiradata.Issue{
+			Key: "TEST-12",
+			Fields: map[string]interface{}{
+				"project":           map[string]interface{}{"key": "TEST", "name": "Test"},
+				"summary":           "Fix the offline search",
+				"status":            map[string]interface{}{"name": "In Progress", "statusCategory": map[string]interface{}{"key": "indeterminate"}},
+				"assignee":          map[string]interface{}{"accountId": "u1", "displayName": "Alice"},
+				"labels":            []interface{}{"cli", "offline"},
+				"updated":           "2020-01-02T10:00:00.000+0000",
+				"customfield_10010": 3.0,
+			},
+		},
+	}
+
+	for query, expected := range map[string]bool{
+		"":                                             true,
+		"project = TEST AND status = 'In Progress'":    true,
+		"project = test and status = done":             false,
+		"assignee = currentUser()":                     true,
+		"labels in (cli, other) and labels not in (x)": true,
+		"summary ~ offline AND NOT summary ~ online":   true,
+		"resolution = unresolved":                      true,
+		"resolution is not empty":                      false,
+		`"Story Points" >= 3 AND cf[10010] < 5`:        true,
+		"updated > 2020-01-01 and updated < -1d":       true,
+		"key = TEST-12 OR (key = TEST-1 AND x = y)":    true,
+		"statusCategory = indeterminate ORDER BY key":  true,
+	} {
+		q, err := parseJQL(query)
+		if !assert.NoError(t, err, query) {
+			continue
+		}
+		ok, err := q.match(env, issue)
+		assert.NoError(t, err, query)
+		assert.Equal(t, expected, ok, query)
+	}
+
+	for _, query := range []string{
+		"status was Done",
+		"sprint in openSprints()",
+		"project = TEST AND",
+		"summary ~ (",
+	} {
+		q, err := parseJQL(query)
+		if err == nil {
+			_, err = q.match(env, issue)
+		}
+		assert.Error(t, err, query)
+	}
+}
@@ -0,0 +1,504 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// OfflineStore is a local mirror of issues used to view and search issues
+// without a connection to Jira.  Changes made while offline are queued in the
+// store to be replayed later.
+type OfflineStore struct {
+	Dir string
+}
+
+// OfflineIssue is an issue in the OfflineStore along with the metadata needed
+// to view, edit and transition it offline.
+type OfflineIssue struct {
+	Issue       *jiradata.Issue           `json:"issue" yaml:"issue"`
+	Transitions *jiradata.TransitionsMeta `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+	EditMeta    *jiradata.EditMeta        `json:"editmeta,omitempty" yaml:"editmeta,omitempty"`
+	RemoteLinks jiradata.RemoteIssueLinks `json:"remotelinks,omitempty" yaml:"remotelinks,omitempty"`
+	Watchers    *jiradata.Watchers        `json:"watchers,omitempty" yaml:"watchers,omitempty"`
+	Synced      time.Time                 `json:"synced" yaml:"synced"`
+}
+
+// OfflineRequest is a change made while offline waiting to be pushed to Jira.
+type OfflineRequest struct {
+	Issue  string          `json:"issue" yaml:"issue"`
+	Method string          `json:"method" yaml:"method"`
+	URI    string          `json:"uri" yaml:"uri"`
+	Body   json.RawMessage `json:"body,omitempty" yaml:"body,omitempty"`
+	// Updated is the `updated` field of the issue when the change was made,
+	// if the issue has been updated since then the change is a conflict
+	Updated string    `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Queued  time.Time `json:"queued" yaml:"queued"`
+}
+
+const (
+	offlineFields     = "fields"
+	offlineMyself     = "myself"
+	offlineQueue      = "queue"
+	offlineServerInfo = "serverInfo"
+)
+
+// LoadJSON will decode the named document from the store into data, it
+// returns false if the document does not exist.
+func (s *OfflineStore) LoadJSON(name string, data interface{}) (bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(s.Dir, name+".json"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(content, data)
+}
+
+// SaveJSON will store data as the named document.
+func (s *OfflineStore) SaveJSON(name string, data interface{}) error {
+	file := filepath.Join(s.Dir, name+".json")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, encoded, 0600)
+}
+
+// Issue returns the issue from the store by key or id, or nil if the issue
+// has not been synced.
+func (s *OfflineStore) Issue(key string) (*OfflineIssue, error) {
+	issue := &OfflineIssue{}
+	if ok, err := s.LoadJSON(filepath.Join("issues", strings.ToUpper(key)), issue); err != nil {
+		return nil, err
+	} else if ok {
+		return issue, nil
+	}
+	// maybe the issue id was used
+	issues, err := s.Issues()
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if issue.Issue.ID == key {
+			return issue, nil
+		}
+	}
+	return nil, nil
+}
+
+// SaveIssue will add or update the issue in the store.
+func (s *OfflineStore) SaveIssue(issue *OfflineIssue) error {
+	return s.SaveJSON(filepath.Join("issues", issue.Issue.Key), issue)
+}
+
+// Issues returns all the issues in the store sorted by key.
+func (s *OfflineStore) Issues() ([]*OfflineIssue, error) {
+	files, err := filepath.Glob(filepath.Join(s.Dir, "issues", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	issues := []*OfflineIssue{}
+	for _, file := range files {
+		issue := &OfflineIssue{}
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		if _, err := s.LoadJSON(filepath.Join("issues", name), issue); err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// Queue returns the changes waiting to be pushed, in the order they were made.
+func (s *OfflineStore) Queue() ([]*OfflineRequest, error) {
+	queue := []*OfflineRequest{}
+	if _, err := s.LoadJSON(offlineQueue, &queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// SaveQueue will replace the changes waiting to be pushed.
+func (s *OfflineStore) SaveQueue(queue []*OfflineRequest) error {
+	if len(queue) == 0 {
+		err := os.Remove(filepath.Join(s.Dir, offlineQueue+".json"))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return s.SaveJSON(offlineQueue, queue)
+}
+
+// SaveFields, SaveServerInfo and SaveMyself store the server metadata needed
+// by the commands that run offline.
+
+func (s *OfflineStore) SaveFields(fields []jiradata.Field) error {
+	return s.SaveJSON(offlineFields, fields)
+}
+
+func (s *OfflineStore) SaveServerInfo(info *jiradata.ServerInfo) error {
+	return s.SaveJSON(offlineServerInfo, info)
+}
+
+func (s *OfflineStore) SaveMyself(user *jiradata.User) error {
+	return s.SaveJSON(offlineMyself, user)
+}
+
+// OfflineTransport is an http.RoundTripper that answers the Jira REST requests
+// from the OfflineStore.  Issues can be fetched and searched, and comments,
+// worklogs, transitions and edits are applied to the mirrored issue and queued
+// in the store.  Any other request will fail.
+type OfflineTransport struct {
+	Store *OfflineStore
+}
+
+type offlineRoute struct {
+	method  string
+	path    *regexp.Regexp
+	handler func(t *OfflineTransport, req *http.Request, body []byte, match []string) (int, interface{}, error)
+}
+
+var offlineRoutes = []offlineRoute{
+	{"GET", regexp.MustCompile(`/rest/api/2/serverInfo$`), offlineDocument(offlineServerInfo)},
+	{"GET", regexp.MustCompile(`/rest/api/2/field$`), offlineDocument(offlineFields)},
+	{"GET", regexp.MustCompile(`/rest/api/2/myself$`), offlineDocument(offlineMyself)},
+	{"GET", regexp.MustCompile(`/rest/api/2/issue/([^/]+)$`), offlineIssueDocument(func(i *OfflineIssue) interface{} { return i.Issue })},
+	{"GET", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/transitions$`), offlineIssueDocument(func(i *OfflineIssue) interface{} { return i.Transitions })},
+	{"GET", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/editmeta$`), offlineIssueDocument(func(i *OfflineIssue) interface{} { return i.EditMeta })},
+	{"GET", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/remotelink$`), offlineIssueDocument(func(i *OfflineIssue) interface{} { return i.RemoteLinks })},
+	{"GET", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/watchers$`), offlineIssueDocument(func(i *OfflineIssue) interface{} { return i.Watchers })},
+	{"POST", regexp.MustCompile(`/rest/api/3/search/jql$`), offlineSearch},
+	{"POST", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/comment$`), offlineChange(201, applyOfflineComment)},
+	{"POST", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/worklog$`), offlineChange(201, applyOfflineWorklog)},
+	{"POST", regexp.MustCompile(`/rest/api/2/issue/([^/]+)/transitions$`), offlineChange(204, applyOfflineTransition)},
+	{"PUT", regexp.MustCompile(`/rest/api/2/issue/([^/]+)$`), offlineChange(204, applyOfflineEdit)},
+}
+
+func (t *OfflineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	status, data := 503, interface{}(nil)
+	var err error
+	found := false
+	for _, route := range offlineRoutes {
+		if route.method != req.Method {
+			continue
+		}
+		if match := route.path.FindStringSubmatch(req.URL.Path); match != nil {
+			found = true
+			status, data, err = route.handler(t, req, body, match)
+			break
+		}
+	}
+	if !found {
+		err = fmt.Errorf("%s %s is not available offline", req.Method, req.URL.Path)
+	}
+	if err != nil {
+		status = 503
+		data = map[string][]string{"errorMessages": {err.Error()}}
+	}
+
+	encoded := []byte{}
+	if data != nil {
+		if encoded, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(encoded)),
+		ContentLength: int64(len(encoded)),
+		Request:       req,
+	}, nil
+}
+
+func offlineDocument(name string) func(*OfflineTransport, *http.Request, []byte, []string) (int, interface{}, error) {
+	return func(t *OfflineTransport, req *http.Request, body []byte, match []string) (int, interface{}, error) {
+		var data interface{}
+		if ok, err := t.Store.LoadJSON(name, &data); err != nil {
+			return 0, nil, err
+		} else if !ok {
+			return 0, nil, fmt.Errorf("%s has not been synced, run `jira sync` while online", name)
+		}
+		return 200, data, nil
+	}
+}
+
+func offlineIssueDocument(field func(*OfflineIssue) interface{}) func(*OfflineTransport, *http.Request, []byte, []string) (int, interface{}, error) {
+	return func(t *OfflineTransport, req *http.Request, body []byte, match []string) (int, interface{}, error) {
+		issue, err := t.Store.Issue(match[1])
+		if err != nil {
+			return 0, nil, err
+		}
+		if issue == nil {
+			return 404, map[string][]string{"errorMessages": {fmt.Sprintf("Issue %s has not been synced for offline use", match[1])}}, nil
+		}
+		return 200, field(issue), nil
+	}
+}
+
+func offlineSearch(t *OfflineTransport, req *http.Request, body []byte, match []string) (int, interface{}, error) {
+	search := &jiradata.SearchRequest{}
+	if err := json.Unmarshal(body, search); err != nil {
+		return 0, nil, err
+	}
+	query, err := parseJQL(search.JQL)
+	if err != nil {
+		return 400, map[string][]string{"errorMessages": {err.Error()}}, nil
+	}
+	issues, err := t.Store.Issues()
+	if err != nil {
+		return 0, nil, err
+	}
+	env := &jqlEnv{}
+	t.Store.LoadJSON(offlineFields, &env.fields)
+	t.Store.LoadJSON(offlineMyself, &env.myself)
+
+	matched := []*OfflineIssue{}
+	for _, issue := range issues {
+		if ok, err := query.match(env, issue); err != nil {
+			return 400, map[string][]string{"errorMessages": {err.Error()}}, nil
+		} else if ok {
+			matched = append(matched, issue)
+		}
+	}
+	query.sort(env, matched)
+
+	results := &jiradata.SearchResults{
+		StartAt:    search.StartAt,
+		MaxResults: search.MaxResults,
+		Total:      len(matched),
+		Issues:     jiradata.Issues{},
+	}
+	for i := search.StartAt; i < len(matched) && (search.MaxResults == 0 || i < search.StartAt+search.MaxResults); i++ {
+		results.Issues = append(results.Issues, offlineSearchIssue(matched[i].Issue, search.Fields))
+	}
+	return 200, results, nil
+}
+
+// offlineSearchIssue returns a copy of the issue with just the requested
+// fields, like the search results from Jira.
+func offlineSearchIssue(issue *jiradata.Issue, fields []string) *jiradata.Issue {
+	for _, field := range fields {
+		if field == "*all" || field == "*navigable" {
+			return issue
+		}
+	}
+	copy := *issue
+	copy.Fields = map[string]interface{}{}
+	for _, field := range fields {
+		if value, ok := issue.Fields[field]; ok {
+			copy.Fields[field] = value
+		}
+	}
+	return &copy
+}
+
+// offlineChange will apply the change to the mirrored issue then queue the
+// request so it can be pushed later.
+func offlineChange(status int, apply func(t *OfflineTransport, issue *OfflineIssue, body []byte) (interface{}, error)) func(*OfflineTransport, *http.Request, []byte, []string) (int, interface{}, error) {
+	return func(t *OfflineTransport, req *http.Request, body []byte, match []string) (int, interface{}, error) {
+		issue, err := t.Store.Issue(match[1])
+		if err != nil {
+			return 0, nil, err
+		}
+		if issue == nil {
+			return 0, nil, fmt.Errorf("Issue %s has not been synced for offline use", match[1])
+		}
+		updated, _ := issue.Issue.Fields["updated"].(string)
+
+		data, err := apply(t, issue, body)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		queue, err := t.Store.Queue()
+		if err != nil {
+			return 0, nil, err
+		}
+		queue = append(queue, &OfflineRequest{
+			Issue:   issue.Issue.Key,
+			Method:  req.Method,
+			URI:     req.URL.String(),
+			Body:    json.RawMessage(body),
+			Updated: updated,
+			Queued:  time.Now(),
+		})
+		if err := t.Store.SaveQueue(queue); err != nil {
+			return 0, nil, err
+		}
+		return status, data, t.Store.SaveIssue(issue)
+	}
+}
+
+func offlineTimestamp() string {
+	return time.Now().Format("2006-01-02T15:04:05.000-0700")
+}
+
+func applyOfflineComment(t *OfflineTransport, issue *OfflineIssue, body []byte) (interface{}, error) {
+	comment := map[string]interface{}{}
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, err
+	}
+	var myself interface{}
+	t.Store.LoadJSON(offlineMyself, &myself)
+	comment["author"] = myself
+	appendOfflineComment(issue.Issue, comment)
+	return comment, nil
+}
+
+func appendOfflineComment(issue *jiradata.Issue, comment map[string]interface{}) {
+	comment["created"] = offlineTimestamp()
+	comment["updated"] = comment["created"]
+	comments, _ := issue.Fields["comment"].(map[string]interface{})
+	if comments == nil {
+		comments = map[string]interface{}{}
+	}
+	list, _ := comments["comments"].([]interface{})
+	comments["comments"] = append(list, comment)
+	comments["total"] = len(list) + 1
+	issue.Fields["comment"] = comments
+}
+
+func applyOfflineWorklog(t *OfflineTransport, issue *OfflineIssue, body []byte) (interface{}, error) {
+	worklog := map[string]interface{}{}
+	if err := json.Unmarshal(body, &worklog); err != nil {
+		return nil, err
+	}
+	var myself interface{}
+	t.Store.LoadJSON(offlineMyself, &myself)
+	worklog["author"] = myself
+	if _, ok := worklog["started"]; !ok {
+		worklog["started"] = offlineTimestamp()
+	}
+
+	worklogs, _ := issue.Issue.Fields["worklog"].(map[string]interface{})
+	if worklogs == nil {
+		worklogs = map[string]interface{}{}
+	}
+	list, _ := worklogs["worklogs"].([]interface{})
+	worklogs["worklogs"] = append(list, worklog)
+	worklogs["total"] = len(list) + 1
+	issue.Issue.Fields["worklog"] = worklogs
+	return worklog, nil
+}
+
+func applyOfflineTransition(t *OfflineTransport, issue *OfflineIssue, body []byte) (interface{}, error) {
+	update := &jiradata.IssueUpdate{}
+	if err := json.Unmarshal(body, update); err != nil {
+		return nil, err
+	}
+	if update.Transition == nil {
+		return nil, fmt.Errorf("Transition missing from request")
+	}
+	var transition *jiradata.Transition
+	if issue.Transitions != nil {
+		for _, trans := range issue.Transitions.Transitions {
+			if trans.ID == update.Transition.ID {
+				transition = trans
+			}
+		}
+	}
+	if transition == nil {
+		return nil, fmt.Errorf("Transition %s is not available for %s offline", update.Transition.ID, issue.Issue.Key)
+	}
+	if transition.To != nil {
+		issue.Issue.Fields["status"] = transition.To
+	}
+	applyOfflineUpdate(issue.Issue, update)
+	return nil, nil
+}
+
+func applyOfflineEdit(t *OfflineTransport, issue *OfflineIssue, body []byte) (interface{}, error) {
+	update := &jiradata.IssueUpdate{}
+	if err := json.Unmarshal(body, update); err != nil {
+		return nil, err
+	}
+	applyOfflineUpdate(issue.Issue, update)
+	return nil, nil
+}
+
+// applyOfflineUpdate will make a best effort to apply the fields and update
+// operations to the mirrored issue so it looks right until the next sync.
+func applyOfflineUpdate(issue *jiradata.Issue, update *jiradata.IssueUpdate) {
+	for field, value := range update.Fields {
+		issue.Fields[field] = value
+	}
+	for field, ops := range update.Update {
+		for _, op := range ops {
+			for verb, value := range op {
+				switch verb {
+				case "set":
+					issue.Fields[field] = value
+				case "add":
+					if comment, ok := value.(map[string]interface{}); ok && field == "comment" {
+						appendOfflineComment(issue, comment)
+						continue
+					}
+					list, _ := issue.Fields[field].([]interface{})
+					issue.Fields[field] = append(list, value)
+				case "remove":
+					list, _ := issue.Fields[field].([]interface{})
+					kept := []interface{}{}
+					for _, item := range list {
+						if fmt.Sprint(item) != fmt.Sprint(value) {
+							kept = append(kept, item)
+						}
+					}
+					issue.Fields[field] = kept
+				}
+			}
+		}
+	}
+}
+
+// ReplayOfflineRequest will send the queued change to Jira.
+func ReplayOfflineRequest(ua HttpClient, req *OfflineRequest) error {
+	var resp *http.Response
+	var err error
+	switch req.Method {
+	case "POST":
+		resp, err = ua.Post(req.URI, "application/json", bytes.NewReader(req.Body))
+	case "PUT":
+		resp, err = ua.Put(req.URI, "application/json", bytes.NewReader(req.Body))
+	default:
+		return fmt.Errorf("Unable to replay %s %s", req.Method, req.URI)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return responseError(resp)
+}
@@ -0,0 +1,188 @@
+package jira
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOfflineStore(t *testing.T) (*OfflineStore, func()) {
+	dir, err := ioutil.TempDir("", "jira-offline")
+	assert.NoError(t, err)
+	store := &OfflineStore{Dir: dir}
+	assert.NoError(t, store.SaveMyself(&jiradata.User{AccountID: "u1", DisplayName: "Alice"}))
+	assert.NoError(t, store.SaveFields([]jiradata.Field{{ID: "summary", Name: "Summary"}}))
+	for _, key := range []string{"TEST-1", "TEST-2"} {
+		assert.NoError(t, store.SaveIssue(&OfflineIssue{
+			Issue: &jiradata.Issue{
+				ID:  strings.Replace(key, "TEST-", "100", 1),
+				Key: key,
+				Fields: map[string]interface{}{
+					"summary": "Issue " + key,
+					"status":  map[string]interface{}{"name": "To Do"},
+					"labels":  []interface{}{"cli"},
+					"updated": "2020-01-02T10:00:00.000+0000",
+				},
+			},
+			Transitions: &jiradata.TransitionsMeta{
+				Transitions: jiradata.Transitions{{ID: "31", Name: "Done", To: &jiradata.Status{Name: "Done"}}},
+			},
+		}))
+	}
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func offlineRoundTrip(t *testing.T, transport *OfflineTransport, method, uri, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(method, "https://jira.example.com"+uri, strings.NewReader(body))
+	assert.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	data := map[string]interface{}{}
+	content, _ := ioutil.ReadAll(resp.Body)
+	if len(content) > 0 {
+		json.Unmarshal(content, &data)
+	}
+	return resp.StatusCode, data
+}
+
+func TestOfflineTransportRoutes(t *testing.T) {
+	store, cleanup := testOfflineStore(t)
+	defer cleanup()
+	transport := &OfflineTransport{Store: store}
+
+	for name, test := range map[string]struct {
+		method string
+		uri    string
+		body   string
+		status int
+		key    string
+		value  interface{}
+	}{
+		"issue":           {"GET", "/rest/api/2/issue/TEST-1", "", 200, "key", "TEST-1"},
+		"issue id":        {"GET", "/rest/api/2/issue/1002", "", 200, "key", "TEST-2"},
+		"lower case key":  {"GET", "/rest/api/2/issue/test-1", "", 200, "key", "TEST-1"},
+		"not synced":      {"GET", "/rest/api/2/issue/TEST-3", "", 404, "errorMessages", []interface{}{"Issue TEST-3 has not been synced for offline use"}},
+		"transitions":     {"GET", "/rest/api/2/issue/TEST-1/transitions", "", 200, "transitions", []interface{}{map[string]interface{}{"id": "31", "name": "Done", "to": map[string]interface{}{"name": "Done"}}}},
+		"myself":          {"GET", "/rest/api/2/myself", "", 200, "displayName", "Alice"},
+		"not synced doc":  {"GET", "/rest/api/2/serverInfo", "", 503, "errorMessages", []interface{}{"serverInfo has not been synced, run `jira sync` while online"}},
+		"unknown route":   {"GET", "/rest/api/2/project", "", 503, "errorMessages", []interface{}{"GET /rest/api/2/project is not available offline"}},
+		"wrong method":    {"DELETE", "/rest/api/2/issue/TEST-1", "", 503, "errorMessages", []interface{}{"DELETE /rest/api/2/issue/TEST-1 is not available offline"}},
+		"search":          {"POST", "/rest/api/3/search/jql", `{"jql":"key = TEST-2","fields":["summary"]}`, 200, "total", 1.0},
+		"search bad jql":  {"POST", "/rest/api/3/search/jql", `{"jql":"status was Done"}`, 400, "", nil},
+		"bad transition":  {"POST", "/rest/api/2/issue/TEST-1/transitions", `{"transition":{"id":"99"}}`, 503, "errorMessages", []interface{}{"Transition 99 is not available for TEST-1 offline"}},
+		"change unsynced": {"POST", "/rest/api/2/issue/TEST-3/comment", `{"body":"hi"}`, 503, "errorMessages", []interface{}{"Issue TEST-3 has not been synced for offline use"}},
+	} {
+		status, data := offlineRoundTrip(t, transport, test.method, test.uri, test.body)
+		assert.Equal(t, test.status, status, name)
+		if test.key != "" {
+			assert.Equal(t, test.value, data[test.key], name)
+		}
+	}
+
+	// none of the reads or failed changes are queued
+	queue, err := store.Queue()
+	assert.NoError(t, err)
+	assert.Empty(t, queue)
+}
+
+func TestOfflineTransportQueue(t *testing.T) {
+	store, cleanup := testOfflineStore(t)
+	defer cleanup()
+	transport := &OfflineTransport{Store: store}
+
+	status, data := offlineRoundTrip(t, transport, "POST", "/rest/api/2/issue/TEST-1/comment", `{"body":"offline comment"}`)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, "offline comment", data["body"])
+	status, _ = offlineRoundTrip(t, transport, "POST", "/rest/api/2/issue/TEST-1/transitions", `{"transition":{"id":"31"}}`)
+	assert.Equal(t, 204, status)
+	status, _ = offlineRoundTrip(t, transport, "PUT", "/rest/api/2/issue/TEST-2", `{"fields":{"summary":"Renamed"},"update":{"labels":[{"add":"new"},{"remove":"cli"}]}}`)
+	assert.Equal(t, 204, status)
+
+	// the changes are queued in order with the updated time of the mirror
+	queue, err := store.Queue()
+	assert.NoError(t, err)
+	if assert.Len(t, queue, 3) {
+		for i, expected := range []struct{ issue, method, uri, body string }{
+			{"TEST-1", "POST", "https://jira.example.com/rest/api/2/issue/TEST-1/comment", `{"body":"offline comment"}`},
+			{"TEST-1", "POST", "https://jira.example.com/rest/api/2/issue/TEST-1/transitions", `{"transition":{"id":"31"}}`},
+			{"TEST-2", "PUT", "https://jira.example.com/rest/api/2/issue/TEST-2", `{"fields":{"summary":"Renamed"},"update":{"labels":[{"add":"new"},{"remove":"cli"}]}}`},
+		} {
+			assert.Equal(t, expected.issue, queue[i].Issue)
+			assert.Equal(t, expected.method, queue[i].Method)
+			assert.Equal(t, expected.uri, queue[i].URI)
+			assert.JSONEq(t, expected.body, string(queue[i].Body))
+			assert.Equal(t, "2020-01-02T10:00:00.000+0000", queue[i].Updated)
+		}
+	}
+
+	// and applied to the mirrored issues
+	issue, err := store.Issue("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Done", issue.Issue.Fields["status"].(map[string]interface{})["name"])
+	comments := issue.Issue.Fields["comment"].(map[string]interface{})
+	assert.Equal(t, 1.0, comments["total"])
+	comment := comments["comments"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "offline comment", comment["body"])
+	assert.Equal(t, "Alice", comment["author"].(map[string]interface{})["displayName"])
+
+	issue, err = store.Issue("TEST-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", issue.Issue.Fields["summary"])
+	assert.Equal(t, []interface{}{"new"}, issue.Issue.Fields["labels"])
+
+	// the search sees the offline changes
+	status, data = offlineRoundTrip(t, transport, "POST", "/rest/api/3/search/jql", `{"jql":"status = Done","fields":["summary"]}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, 1.0, data["total"])
+	assert.Equal(t, "TEST-1", data["issues"].([]interface{})[0].(map[string]interface{})["key"])
+}
+
+func TestReplayOfflineRequest(t *testing.T) {
+	type request struct{ method, path, body string }
+	received := []request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, request{r.Method, r.URL.Path, string(body)})
+		if strings.HasSuffix(r.URL.Path, "/TEST-3/comment") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(400)
+			w.Write([]byte(`{"errorMessages":["Comment body can not be empty!"]}`))
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+	ua := oreo.New().WithRetries(0)
+
+	for name, test := range map[string]struct {
+		req   *OfflineRequest
+		error string
+	}{
+		"post":   {&OfflineRequest{Method: "POST", URI: server.URL + "/rest/api/2/issue/TEST-1/comment", Body: json.RawMessage(`{"body":"hi"}`)}, ""},
+		"put":    {&OfflineRequest{Method: "PUT", URI: server.URL + "/rest/api/2/issue/TEST-2", Body: json.RawMessage(`{"fields":{}}`)}, ""},
+		"failed": {&OfflineRequest{Method: "POST", URI: server.URL + "/rest/api/2/issue/TEST-3/comment", Body: json.RawMessage(`{}`)}, "Comment body can not be empty!"},
+		"delete": {&OfflineRequest{Method: "DELETE", URI: server.URL + "/rest/api/2/issue/TEST-4"}, "Unable to replay DELETE"},
+	} {
+		received = received[:0]
+		err := ReplayOfflineRequest(ua, test.req)
+		if test.error == "" {
+			assert.NoError(t, err, name)
+		} else if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), test.error, name)
+		}
+		if test.req.Method == "DELETE" {
+			assert.Empty(t, received, name)
+		} else if assert.Len(t, received, 1, name) {
+			assert.Equal(t, request{test.req.Method, strings.TrimPrefix(test.req.URI, server.URL), string(test.req.Body)}, received[0], name)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue/{issueIdOrKey}/properties-getPropertiesKeys
+func (j *Jira) GetIssuePropertyKeys(issue string) (*jiradata.PropertyKeys, error) {
+	return GetIssuePropertyKeys(j.UA, j.Endpoint, issue)
+}
+
+func GetIssuePropertyKeys(ua HttpClient, endpoint string, issue string) (*jiradata.PropertyKeys, error) {
+	return getPropertyKeys(ua, URLJoin(endpoint, "rest/api/2/issue", issue, "properties"))
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue/{issueIdOrKey}/properties-getProperty
+func (j *Jira) GetIssueProperty(issue, key string) (*jiradata.EntityProperty, error) {
+	return GetIssueProperty(j.UA, j.Endpoint, issue, key)
+}
+
+func GetIssueProperty(ua HttpClient, endpoint string, issue, key string) (*jiradata.EntityProperty, error) {
+	return getProperty(ua, URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key))
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue/{issueIdOrKey}/properties-setProperty
+func (j *Jira) SetIssueProperty(issue, key string, value interface{}) error {
+	return SetIssueProperty(j.UA, j.Endpoint, issue, key, value)
+}
+
+func SetIssueProperty(ua HttpClient, endpoint string, issue, key string, value interface{}) error {
+	return setProperty(ua, URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key), value)
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue/{issueIdOrKey}/properties-deleteProperty
+func (j *Jira) DeleteIssueProperty(issue, key string) error {
+	return DeleteIssueProperty(j.UA, j.Endpoint, issue, key)
+}
+
+func DeleteIssueProperty(ua HttpClient, endpoint string, issue, key string) error {
+	return deleteProperty(ua, URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key))
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/project/{projectIdOrKey}/properties-getPropertiesKeys
+func (j *Jira) GetProjectPropertyKeys(project string) (*jiradata.PropertyKeys, error) {
+	return GetProjectPropertyKeys(j.UA, j.Endpoint, project)
+}
+
+func GetProjectPropertyKeys(ua HttpClient, endpoint string, project string) (*jiradata.PropertyKeys, error) {
+	return getPropertyKeys(ua, URLJoin(endpoint, "rest/api/2/project", project, "properties"))
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/project/{projectIdOrKey}/properties-getProperty
+func (j *Jira) GetProjectProperty(project, key string) (*jiradata.EntityProperty, error) {
+	return GetProjectProperty(j.UA, j.Endpoint, project, key)
+}
+
+func GetProjectProperty(ua HttpClient, endpoint string, project, key string) (*jiradata.EntityProperty, error) {
+	return getProperty(ua, URLJoin(endpoint, "rest/api/2/project", project, "properties", key))
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/project/{projectIdOrKey}/properties-setProperty
+func (j *Jira) SetProjectProperty(project, key string, value interface{}) error {
+	return SetProjectProperty(j.UA, j.Endpoint, project, key, value)
+}
+
+func SetProjectProperty(ua HttpClient, endpoint string, project, key string, value interface{}) error {
+	return setProperty(ua, URLJoin(endpoint, "rest/api/2/project", project, "properties", key), value)
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/project/{projectIdOrKey}/properties-deleteProperty
+func (j *Jira) DeleteProjectProperty(project, key string) error {
+	return DeleteProjectProperty(j.UA, j.Endpoint, project, key)
+}
+
+func DeleteProjectProperty(ua HttpClient, endpoint string, project, key string) error {
+	return deleteProperty(ua, URLJoin(endpoint, "rest/api/2/project", project, "properties", key))
+}
+
+// the issue and project property APIs are identical apart from the
+// entity prefix, so they share these helpers
+
+func getPropertyKeys(ua HttpClient, uri string) (*jiradata.PropertyKeys, error) {
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.PropertyKeys{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+func getProperty(ua HttpClient, uri string) (*jiradata.EntityProperty, error) {
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.EntityProperty{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+func setProperty(ua HttpClient, uri string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	resp, err := ua.Put(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 201 when the property is created, 200 when it is updated
+	if resp.StatusCode == 200 || resp.StatusCode == 201 {
+		return nil
+	}
+	return responseError(resp)
+}
+
+func deleteProperty(ua HttpClient, uri string) error {
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
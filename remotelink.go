@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue-getRemoteIssueLinks
+func (j *Jira) GetRemoteLinks(issue string) (*jiradata.RemoteIssueLinks, error) {
+	return GetRemoteLinks(j.UA, j.Endpoint, issue)
+}
+
+func GetRemoteLinks(ua HttpClient, endpoint string, issue string) (*jiradata.RemoteIssueLinks, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := jiradata.RemoteIssueLinks{}
+		return &results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+type RemoteIssueLinkProvider interface {
+	ProvideRemoteIssueLink() *jiradata.RemoteIssueLink
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue-createOrUpdateRemoteIssueLink
+func (j *Jira) CreateRemoteLink(issue string, rlp RemoteIssueLinkProvider) (*jiradata.RemoteIssueLink, error) {
+	return CreateRemoteLink(j.UA, j.Endpoint, issue, rlp)
+}
+
+func CreateRemoteLink(ua HttpClient, endpoint string, issue string, rlp RemoteIssueLinkProvider) (*jiradata.RemoteIssueLink, error) {
+	req := rlp.ProvideRemoteIssueLink()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink")
+	resp, err := ua.Post(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// 201 when a new link is created, 200 when an existing link with the
+	// same globalId is updated
+	if resp.StatusCode == 201 || resp.StatusCode == 200 {
+		results := &jiradata.RemoteIssueLink{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/software/jira/docs/api/REST/7.12.0/#api/2/issue-deleteRemoteIssueLinkById
+func (j *Jira) DeleteRemoteLink(issue, id string) error {
+	return DeleteRemoteLink(j.UA, j.Endpoint, issue, id)
+}
+
+func DeleteRemoteLink(ua HttpClient, endpoint string, issue, id string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink", id)
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
@@ -136,7 +136,7 @@ func Search(ua HttpClient, endpoint string, sp SearchProvider, opts ...SearchOpt
 			return page, nil
 		}
 		req.StartAt = len(issues)
-		if len(issues)+req.MaxResults > limit {
+		if limit > 0 && len(issues)+req.MaxResults > limit {
 			req.MaxResults = limit - len(issues)
 		}
 	}
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAutoPagination(t *testing.T) {
+	const total = 250
+	pages := []int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &jiradata.SearchRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		pages = append(pages, req.MaxResults)
+		if req.MaxResults < 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(400)
+			w.Write([]byte(`{"errorMessages":["maxResults must be positive"]}`))
+			return
+		}
+		// like Jira the page size is capped at 100
+		results := &jiradata.SearchResults{StartAt: req.StartAt, MaxResults: req.MaxResults, Total: total, Issues: jiradata.Issues{}}
+		for i := req.StartAt; i < total && i < req.StartAt+req.MaxResults && i < req.StartAt+100; i++ {
+			results.Issues = append(results.Issues, &jiradata.Issue{Key: fmt.Sprintf("TEST-%d", i+1)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+
+	for name, test := range map[string]struct {
+		limit  int
+		pages  []int
+		issues int
+	}{
+		"no limit":       {0, []int{100, 100, 100}, total},
+		"limit":          {150, []int{150, 50}, 150},
+		"limit one page": {20, []int{20}, 20},
+	} {
+		pages = nil
+		results, err := Search(oreo.New().WithRetries(0), server.URL, &SearchOptions{Query: "project = TEST", MaxResults: test.limit}, WithAutoPagination())
+		if assert.NoError(t, err, name) {
+			assert.Len(t, results.Issues, test.issues, name)
+			assert.Equal(t, "TEST-1", results.Issues[0].Key, name)
+		}
+		assert.Equal(t, test.pages, pages, name)
+	}
+}
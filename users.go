@@ -53,3 +53,43 @@ func UserSearch(ua HttpClient, endpoint string, opts *UserSearchOptions) ([]*jir
 	}
 	return nil, responseError(resp)
 }
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-user-get
+func (j *Jira) GetUser(accountID string) (*jiradata.User, error) {
+	return GetUser(j.UA, j.Endpoint, accountID)
+}
+
+func GetUser(ua HttpClient, endpoint string, accountID string) (*jiradata.User, error) {
+	uri := URLJoin(endpoint, "rest/api/2/user") + "?accountId=" + url.QueryEscape(accountID) + "&expand=groups"
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.User{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-myself-get
+func (j *Jira) Myself() (*jiradata.User, error) {
+	return Myself(j.UA, j.Endpoint)
+}
+
+func Myself(ua HttpClient, endpoint string) (*jiradata.User, error) {
+	uri := URLJoin(endpoint, "rest/api/2/myself") + "?expand=groups"
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.User{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}